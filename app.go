@@ -37,6 +37,11 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
+	// Refresh an existing GSI install so its cfg carries the current auth token
+	if a.IsGSIInstalled() {
+		a.gsiInstaller.Install()
+	}
+
 	// Start the embedded backend server
 	go func() {
 		time.Sleep(100 * time.Millisecond) // Small delay to ensure context is ready
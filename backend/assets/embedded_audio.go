@@ -10,6 +10,8 @@ import (
 // ============================================================================
 // This package embeds default audio files into the binary for the free version.
 // These are generic messages like "Runa de Poder em alguns segundos"
+// (generated by scripts/generate_free_audio.go). Events without a file here
+// are spoken by the frontend with the system voice.
 
 //go:embed audio
 var AudioFiles embed.FS
@@ -0,0 +1,107 @@
+package main
+
+// Headless replay: feeds a recorded match through the consumer pipeline and
+// prints every domain event instead of playing audio.
+//
+// Usage:
+//
+//	go run ./cmd/replay [-speed 1|10|max] [-seek 600] [-config config.json] <recording.ndjson.gz>
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/consumers"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/replay"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// drainTimeout bounds how long the command waits for consumers after the last tick
+const drainTimeout = 10 * time.Second
+
+// printHandler prints domain events to stdout
+type printHandler struct {
+	start time.Time
+}
+
+// Handle prints a single domain event
+func (ph *printHandler) Handle(eventType string, data interface{}) {
+	payload, _ := json.Marshal(data)
+	fmt.Printf("[+%7.2fs] %-22s %s\n", time.Since(ph.start).Seconds(), eventType, payload)
+}
+
+func main() {
+	speedFlag := flag.String("speed", "max", "Speed multiplier: 1, 10, ... or max")
+	seekFlag := flag.Int64("seek", -1, "Start at this game clock (seconds, map.clock_time)")
+	configFlag := flag.String("config", "", "Game config file (defaults to built-in config)")
+	verbose := flag.Bool("v", false, "Show consumer logs")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: replay [-speed 1|10|max] [-seek seconds] [-config file] <recording.ndjson.gz>")
+		os.Exit(2)
+	}
+
+	if *verbose {
+		logrus.SetLevel(logrus.DebugLevel)
+	} else {
+		logrus.SetLevel(logrus.WarnLevel)
+	}
+	logger := logrus.WithField("component", "replay-cli")
+
+	speed, err := replay.ParseSpeed(*speedFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Load game config (built-in defaults unless a file is given)
+	gameConfig := config.DefaultGameConfig()
+	if *configFlag != "" {
+		if gameConfig, err = config.LoadGameConfig(*configFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Build the same pipeline as the server, with a printing handler
+	eventBus := events.NewEventBus()
+	handlerList := []handlers.Handler{&printHandler{start: time.Now()}}
+
+	consumerManager := consumers.NewConsumerManager(logger.WithField("component", "consumers"))
+	consumerManager.AddGameConsumers(eventBus, handlerList, gameConfig)
+	consumerManager.StartAll()
+
+	var seek *int64
+	if *seekFlag >= 0 {
+		seek = seekFlag
+	}
+
+	player := replay.NewPlayer(eventBus, logger)
+	player.SetOnReset(consumerManager.ResetAll)
+	if err := player.Start(flag.Arg(0), speed, seek); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start replay: %v\n", err)
+		os.Exit(1)
+	}
+
+	<-player.Done()
+
+	// Let consumers take every queued tick before stopping them
+	if !eventBus.WaitDrained(drainTimeout) {
+		fmt.Fprintln(os.Stderr, "warning: consumers did not drain in time, last alerts may be missing")
+	}
+	consumerManager.StopAll()
+
+	status := player.Status()
+	fmt.Printf("replay %s: %d ticks published\n", status["state"], status["published"])
+	if status["state"] == replay.StateError {
+		fmt.Fprintf(os.Stderr, "error: %s\n", status["error"])
+		os.Exit(1)
+	}
+}
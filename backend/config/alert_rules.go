@@ -0,0 +1,156 @@
+package config
+
+import "errors"
+
+// ============================================================================
+// Alert Rules
+// ============================================================================
+// User-defined alerts on any GSI field, written as conditions like
+// "hero.health_percent < 30 && hero.alive" or "player.gold > 4000 for 20s".
+// Like custom timers, each rule owns a timing (enabled, throttle), a message
+// and event metadata under its key.
+
+// AlertRulePrefix starts every alert rule key
+const AlertRulePrefix = "rule_"
+
+// ErrAlertRuleExists is returned by AddAlertRule when the key is taken
+var ErrAlertRuleExists = errors.New("alert rule already exists")
+
+// AlertRule is a user-defined alert on the GSI tick
+type AlertRule struct {
+	Key       string `json:"key"`       // AlertRulePrefix + slug of the name
+	Name      string `json:"name"`      // Shown in the event list
+	Condition string `json:"condition"` // Expression over the tick (see the rules package)
+}
+
+// AlertRuleKey builds the key of an alert rule from its name
+// ("Rich" -> "rule_rich"). Empty if the name has no letters or digits.
+func AlertRuleKey(name string) string {
+	return prefixedSlug(AlertRulePrefix, name)
+}
+
+// GetAlertRules returns the user-defined alert rules
+func (gc *GameConfig) GetAlertRules() []AlertRule {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.Rules // Replaced, never modified in place
+}
+
+// GetAlertRule returns an alert rule by key
+func (gc *GameConfig) GetAlertRule(key string) (AlertRule, bool) {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	for _, rule := range gc.Rules {
+		if rule.Key == key {
+			return rule, true
+		}
+	}
+	return AlertRule{}, false
+}
+
+// AddAlertRule adds a new alert rule like SetAlertRule, but fails with
+// ErrAlertRuleExists if a rule already uses its key
+func (gc *GameConfig) AddAlertRule(rule AlertRule, message string, throttle int64) error {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	for _, existing := range gc.Rules {
+		if existing.Key == rule.Key {
+			return ErrAlertRuleExists
+		}
+	}
+	gc.setAlertRule(rule, message, throttle)
+	return nil
+}
+
+// SetAlertRule adds or replaces an alert rule along with its timing
+// (throttle in game seconds), message and event metadata
+func (gc *GameConfig) SetAlertRule(rule AlertRule, message string, throttle int64) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	gc.setAlertRule(rule, message, throttle)
+}
+
+// setAlertRule stores an alert rule (caller holds gc.mu)
+func (gc *GameConfig) setAlertRule(rule AlertRule, message string, throttle int64) {
+	replaced := false
+	rules := make([]AlertRule, 0, len(gc.Rules)+1)
+	for _, existing := range gc.Rules {
+		if existing.Key == rule.Key {
+			existing = rule
+			replaced = true
+		}
+		rules = append(rules, existing)
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+	gc.Rules = rules
+
+	// Timing (keeps the enabled flag across edits)
+	enabled := true
+	if timing, exists := gc.Timings[rule.Key]; exists {
+		if val, ok := timing["enabled"].(bool); ok {
+			enabled = val
+		}
+	}
+	if gc.Timings == nil {
+		gc.Timings = make(map[string]map[string]interface{})
+	}
+	gc.Timings[rule.Key] = map[string]interface{}{
+		"enabled":  enabled,
+		"throttle": throttle,
+	}
+
+	// Message
+	if message == "" {
+		message = rule.Name
+	}
+	if gc.Messages == nil {
+		gc.Messages = make(map[string]string)
+	}
+	gc.Messages[rule.Key] = message
+
+	// Event metadata
+	if gc.Events == nil {
+		gc.Events = make(map[string]TimingEvent)
+	}
+	gc.Events[rule.Key] = TimingEvent{
+		Enabled:        enabled,
+		WarningSeconds: int(throttle),
+		Min:            0,
+		Max:            300,
+		Step:           5,
+		Name:           rule.Name,
+		Description:    rule.Condition,
+		Category:       "rule",
+		Field:          "throttle",
+	}
+}
+
+// RemoveAlertRule deletes an alert rule and everything stored under its key
+func (gc *GameConfig) RemoveAlertRule(key string) bool {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	removed := false
+	rules := make([]AlertRule, 0, len(gc.Rules))
+	for _, rule := range gc.Rules {
+		if rule.Key == key {
+			removed = true
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if !removed {
+		return false
+	}
+	gc.Rules = rules
+
+	delete(gc.Timings, key)
+	delete(gc.Messages, key)
+	delete(gc.Events, key)
+	return true
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
 	"sync"
 )
 
@@ -12,6 +16,7 @@ import (
 // - Server settings (port)
 // - ElevenLabs voice configuration (API key, voice ID)
 // - Voice cache path
+// - GSI auth token
 // - Game configuration
 
 // Singleton instance and mutex for thread-safe access
@@ -134,3 +139,63 @@ func (c *Config) UpdateVoiceConfig(apiKey, voiceID string) error {
 	configPath, _ := GetConfigPath()
 	return SaveGameConfig(configPath, c.Game)
 }
+
+// GetGSIToken returns the GSI auth token (empty if none was generated yet)
+func (c *Config) GetGSIToken() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if c.Game == nil || c.Game.System == nil {
+		return ""
+	}
+	return c.Game.System.GSIToken
+}
+
+// EnsureGSIToken returns the GSI auth token, generating and saving a new one if needed.
+// The token is kept out of config.json (served by /api/config) in a file only the user can read.
+func (c *Config) EnsureGSIToken() (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if c.Game.System == nil {
+		c.Game.System = &SystemConfig{}
+	}
+	if c.Game.System.GSIToken != "" {
+		return c.Game.System.GSIToken, nil
+	}
+
+	tokenPath, err := GetGSITokenPath()
+	if err != nil {
+		return "", err
+	}
+
+	// Token saved by a previous run
+	if data, err := os.ReadFile(tokenPath); err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			c.Game.System.GSIToken = token
+			return token, nil
+		}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate GSI token: %w", err)
+	}
+
+	// Save to disk
+	if err := os.WriteFile(tokenPath, []byte(token), 0600); err != nil {
+		return "", fmt.Errorf("failed to save GSI token: %w", err)
+	}
+	c.Game.System.GSIToken = token
+
+	return token, nil
+}
+
+// generateToken returns a random hex token
+func generateToken() (string, error) {
+	buf := make([]byte, GSITokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -19,12 +19,53 @@ const (
 	DefaultVoiceSpeed   = 1.0
 
 	// Timing defaults (used as fallbacks if config is not set)
-	DefaultCatapultWarning  = 15
-	DefaultDayNightWarning  = 20
-	DefaultStackWarning     = 20
-	DefaultRuneWarning      = 30
+	DefaultCatapultWarning    = 15
+	DefaultDayNightWarning    = 20
+	DefaultStackWarning       = 20
+	DefaultRuneWarning        = 30
+	DefaultRoshanWarning      = 30 // Seconds before each Roshan respawn window
+	DefaultAegisWarning       = 30 // Seconds before the Aegis expires
+	DefaultTormentorSpawn     = 20 // Minute the Tormentors first spawn
+	DefaultTormentorWarning   = 30
+	DefaultLotusWarning       = 20
+	DefaultOutpostWarning     = 20
+	DefaultBuybackStartMinute = 30 // Game minute buyback warnings start at
+
+	// Hero alert defaults (thresholds in percent, throttles in seconds)
+	DefaultHealthLowThreshold      = 25
+	DefaultHealthCriticalThreshold = 10
+	DefaultManaLowThreshold        = 15
+	DefaultHealthLowThrottle       = 5
+	DefaultHealthCriticalThrottle  = 3
+	DefaultManaLowThrottle         = 3
+	DefaultRespawnCountdown        = 5 // Last respawn seconds spoken by the countdown
+
+	// Hero status alerts (smoked, silenced, hexed...) repeat at most every N game seconds
+	DefaultStatusThrottle = 5
+
+	// Draft warnings (seconds left on our pick timer / reserve time)
+	DefaultDraftPickWarning    = 10
+	DefaultDraftReserveWarning = 30
+
+	// Purchase announcements are opt-in (they'd fire on every buy)
+	DefaultItemPurchasedAnnounce = false
+
+	// Pause announcements ("game paused" / "game unpaused") are opt-in
+	DefaultPauseAnnounce = false
+
+	// Alert rules fire at most every N game seconds (unless the rule sets its own throttle)
+	DefaultRuleThrottle = 10
+
+	// Timing schedule: "auto" picks the patch from provider.version
+	DefaultSchedule = "auto"
 
 	// System defaults
 	DefaultFirstRun     = true
 	DefaultGSIInstalled = false
+
+	// Recording defaults
+	DefaultRecordingEnabled = false
+
+	// GSI auth defaults
+	GSITokenBytes = 16 // Random bytes in the per-install GSI token (hex encoded)
 )
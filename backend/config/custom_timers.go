@@ -0,0 +1,163 @@
+package config
+
+import (
+	"strings"
+)
+
+// ============================================================================
+// Custom Timers
+// ============================================================================
+// User-defined timers ("every 45s: check minimap", "at 12:00: farm lotus").
+// Each one owns a timing, a message and event metadata under its key, so it is
+// toggled, edited and voiced exactly like the built-in timings.
+
+// CustomTimerPrefix starts every custom timer key
+const CustomTimerPrefix = "custom_"
+
+// CustomTimer is a user-defined timer (clock_time seconds)
+type CustomTimer struct {
+	Key      string  `json:"key"`                // CustomTimerPrefix + slug of the name
+	Name     string  `json:"name"`               // Shown in the event list
+	First    int64   `json:"first"`              // Clock of the first occurrence
+	Interval int64   `json:"interval,omitempty"` // Seconds between occurrences (0 = only once)
+	Until    int64   `json:"until,omitempty"`    // Clock of the last possible occurrence (0 = no end)
+	Warnings []int64 `json:"warnings,omitempty"` // Seconds before each occurrence to announce (0 = when it happens)
+}
+
+// CustomTimerKey builds the key of a custom timer from its name
+// ("Check minimap" -> "custom_check_minimap"). Empty if the name has no letters or digits.
+func CustomTimerKey(name string) string {
+	return prefixedSlug(CustomTimerPrefix, name)
+}
+
+// prefixedSlug lowercases a name, joins its words with "_" and adds the prefix
+func prefixedSlug(prefix, name string) string {
+	var slug strings.Builder
+	separator := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if separator && slug.Len() > 0 {
+				slug.WriteByte('_')
+			}
+			slug.WriteRune(r)
+			separator = false
+		} else {
+			separator = true
+		}
+	}
+
+	if slug.Len() == 0 {
+		return ""
+	}
+	return prefix + slug.String()
+}
+
+// GetCustomTimers returns the user-defined timers
+func (gc *GameConfig) GetCustomTimers() []CustomTimer {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.Timers // Replaced, never modified in place
+}
+
+// GetCustomTimer returns a user-defined timer by key
+func (gc *GameConfig) GetCustomTimer(key string) (CustomTimer, bool) {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	for _, timer := range gc.Timers {
+		if timer.Key == key {
+			return timer, true
+		}
+	}
+	return CustomTimer{}, false
+}
+
+// SetCustomTimer adds or replaces a custom timer along with its timing,
+// message and event metadata. The first warning is stored as warning_seconds
+// so it can be tuned like any other timing.
+func (gc *GameConfig) SetCustomTimer(timer CustomTimer, message string) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if len(timer.Warnings) == 0 {
+		timer.Warnings = []int64{0}
+	}
+
+	replaced := false
+	timers := make([]CustomTimer, 0, len(gc.Timers)+1)
+	for _, existing := range gc.Timers {
+		if existing.Key == timer.Key {
+			existing = timer
+			replaced = true
+		}
+		timers = append(timers, existing)
+	}
+	if !replaced {
+		timers = append(timers, timer)
+	}
+	gc.Timers = timers
+
+	// Timing (keeps the enabled flag across edits)
+	enabled := true
+	if timing, exists := gc.Timings[timer.Key]; exists {
+		if val, ok := timing["enabled"].(bool); ok {
+			enabled = val
+		}
+	}
+	if gc.Timings == nil {
+		gc.Timings = make(map[string]map[string]interface{})
+	}
+	gc.Timings[timer.Key] = map[string]interface{}{
+		"enabled":         enabled,
+		"warning_seconds": timer.Warnings[0],
+	}
+
+	// Message
+	if message == "" {
+		message = timer.Name
+	}
+	if gc.Messages == nil {
+		gc.Messages = make(map[string]string)
+	}
+	gc.Messages[timer.Key] = message
+
+	// Event metadata
+	if gc.Events == nil {
+		gc.Events = make(map[string]TimingEvent)
+	}
+	gc.Events[timer.Key] = TimingEvent{
+		Enabled:        enabled,
+		WarningSeconds: int(timer.Warnings[0]),
+		Min:            0,
+		Max:            300,
+		Step:           5,
+		Name:           timer.Name,
+		Description:    message,
+		Category:       "custom",
+	}
+}
+
+// RemoveCustomTimer deletes a custom timer and everything stored under its key
+func (gc *GameConfig) RemoveCustomTimer(key string) bool {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	removed := false
+	timers := make([]CustomTimer, 0, len(gc.Timers))
+	for _, timer := range gc.Timers {
+		if timer.Key == key {
+			removed = true
+			continue
+		}
+		timers = append(timers, timer)
+	}
+	if !removed {
+		return false
+	}
+	gc.Timers = timers
+
+	delete(gc.Timings, key)
+	delete(gc.Messages, key)
+	delete(gc.Events, key)
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"dota-gsi/backend/i18n"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // DefaultGameConfig returns the default game configuration
@@ -11,6 +12,7 @@ func DefaultGameConfig() *GameConfig {
 	return &GameConfig{
 		Mode:     "free",  // Default mode (free version)
 		Language: "pt-BR", // Default language
+		Schedule: DefaultSchedule,
 		Timings: map[string]map[string]interface{}{
 			"bounty_rune": {
 				"enabled":         true,
@@ -45,47 +47,204 @@ func DefaultGameConfig() *GameConfig {
 				"warning_seconds": 0,
 			},
 			"tormentor": {
-				"enabled": true,
-				"time":    20,
+				"enabled":         true,
+				"time":            DefaultTormentorSpawn, // Minute of the first spawn (respawns 10min after each kill)
+				"warning_seconds": DefaultTormentorWarning,
 			},
 			"roshan": {
 				"enabled": true,
-				"minimum": 30,
-				"maximum": 30,
+				"minimum": DefaultRoshanWarning, // Warn before the minimum respawn (8:00 after the kill)
+				"maximum": DefaultRoshanWarning, // Warn before the maximum respawn (11:00 after the kill)
+				"aegis":   DefaultAegisWarning,  // Warn before the Aegis expires
 			},
 			"glyph": {
 				"enabled": true,
-				"time":    30,
+				"enemy":   false, // Also announce the enemy glyph (needs spectator data and the player's team)
 			},
 			"buyback": {
 				"enabled": true,
-				"time":    30,
+				"time":    DefaultBuybackStartMinute, // Minute the buyback cooldown/gold warnings start
 			},
 			"lotus": {
-				"enabled": true,
-				"time":    20,
+				"enabled":         true,
+				"warning_seconds": DefaultLotusWarning, // Healing Lotus grows at 3:00, then every 3min
 			},
 			"outpost": {
+				"enabled":         true,
+				"warning_seconds": DefaultOutpostWarning, // Outposts grant XP at 10:00, then every 10min
+			},
+			"tp_ready": {
+				"enabled": true,
+			},
+			"item_purchased": {
+				"enabled": DefaultItemPurchasedAnnounce,
+			},
+			"item_ready": {
+				"enabled": true, // Items in the watch list coming off cooldown
+			},
+			"ultimate_ready": {
+				"enabled": true,
+			},
+			"ability_ready": {
+				"enabled": true, // Abilities in the hero's watch list coming off cooldown
+			},
+			"game_pause": {
+				"enabled": DefaultPauseAnnounce,
+			},
+			"draft_pick_timer": {
+				"enabled":         true,
+				"warning_seconds": DefaultDraftPickWarning, // Seconds left on our pick/ban timer
+			},
+			"draft_reserve_time": {
+				"enabled":         true,
+				"warning_seconds": DefaultDraftReserveWarning, // Seconds left of our reserve time
+			},
+			"strategy_time": {
+				"enabled": true,
+			},
+			"hero_health_low": {
+				"enabled":   true,
+				"threshold": DefaultHealthLowThreshold, // Health percent
+				"throttle":  DefaultHealthLowThrottle,  // Seconds between two alerts
+			},
+			"hero_health_critical": {
+				"enabled":   true,
+				"threshold": DefaultHealthCriticalThreshold,
+				"throttle":  DefaultHealthCriticalThrottle,
+			},
+			"hero_mana_low": {
+				"enabled":   true,
+				"threshold": DefaultManaLowThreshold, // Mana percent
+				"throttle":  DefaultManaLowThrottle,
+			},
+			"hero_death": {
+				"enabled": true, // Also says the respawn time and whether buyback is available
+			},
+			"hero_respawn_countdown": {
+				"enabled":         false,
+				"warning_seconds": DefaultRespawnCountdown, // Count down the last seconds of the respawn
+			},
+			"hero_respawned": {
 				"enabled": true,
-				"time":    20,
+			},
+			"hero_level_up": {
+				"enabled": false,
+			},
+			"game_state_change": {
+				"enabled": false, // Draft, strategy time and match start/end have their own events
+			},
+			"day_night_change": {
+				"enabled": false, // day_night_cycle already announces the transition
+			},
+			"score_change": {
+				"enabled": false,
+			},
+			"status_smoked": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"smoke_broken": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_silenced": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_stunned": {
+				"enabled":  false,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_hexed": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_broken": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_magic_immune": {
+				"enabled":  false,
+				"throttle": DefaultStatusThrottle,
+			},
+			"magic_immune_ended": {
+				"enabled":  false,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_muted": {
+				"enabled":  true,
+				"throttle": DefaultStatusThrottle,
+			},
+			"status_disarmed": {
+				"enabled":  false,
+				"throttle": DefaultStatusThrottle,
 			},
 		},
 		Audio: AudioConfig{
 			VoiceSpeed: DefaultVoiceSpeed,
 		},
 		Messages: map[string]string{
-			"bounty_rune":     i18n.T("messages.bounty_rune", map[string]interface{}{"seconds": "{seconds}"}),
-			"power_rune":      i18n.T("messages.power_rune", map[string]interface{}{"seconds": "{seconds}"}),
-			"wisdom_rune":     i18n.T("messages.wisdom_rune", map[string]interface{}{"seconds": "{seconds}"}),
-			"water_rune":      i18n.T("messages.water_rune", map[string]interface{}{"seconds": "{seconds}"}),
-			"stack_timing":    i18n.T("messages.stack_timing", map[string]interface{}{"seconds": "{seconds}"}),
-			"catapult_timing": i18n.T("messages.catapult_timing", map[string]interface{}{"seconds": "{seconds}"}),
-			"day_night_cycle": i18n.T("messages.day_night_cycle", map[string]interface{}{"seconds": "{seconds}"}),
+			"bounty_rune":            i18n.T("messages.bounty_rune", map[string]interface{}{"seconds": "{seconds}"}),
+			"power_rune":             i18n.T("messages.power_rune", map[string]interface{}{"seconds": "{seconds}"}),
+			"wisdom_rune":            i18n.T("messages.wisdom_rune", map[string]interface{}{"seconds": "{seconds}"}),
+			"water_rune":             i18n.T("messages.water_rune", map[string]interface{}{"seconds": "{seconds}"}),
+			"stack_timing":           i18n.T("messages.stack_timing", map[string]interface{}{"seconds": "{seconds}"}),
+			"catapult_timing":        i18n.T("messages.catapult_timing", map[string]interface{}{"seconds": "{seconds}"}),
+			"day_night_cycle":        i18n.T("messages.day_night_cycle", map[string]interface{}{"seconds": "{seconds}"}),
+			"roshan_killed":          i18n.T("messages.roshan_killed", map[string]interface{}{"drops": "{drops}"}),
+			"roshan_min_window":      i18n.T("messages.roshan_min_window", map[string]interface{}{"seconds": "{seconds}"}),
+			"roshan_max_window":      i18n.T("messages.roshan_max_window", map[string]interface{}{"seconds": "{seconds}"}),
+			"aegis_expiring":         i18n.T("messages.aegis_expiring", map[string]interface{}{"seconds": "{seconds}"}),
+			"lotus":                  i18n.T("messages.lotus", map[string]interface{}{"seconds": "{seconds}"}),
+			"outpost":                i18n.T("messages.outpost", map[string]interface{}{"seconds": "{seconds}"}),
+			"buyback_ready":          i18n.T("messages.buyback_ready", nil),
+			"buyback_gold_low":       i18n.T("messages.buyback_gold_low", map[string]interface{}{"missing": "{missing}"}),
+			"buyback_available":      i18n.T("messages.buyback_available", nil),
+			"glyph_available":        i18n.T("messages.glyph_available", nil),
+			"enemy_glyph_available":  i18n.T("messages.enemy_glyph_available", nil),
+			"tp_ready":               i18n.T("messages.tp_ready", nil),
+			"item_purchased":         i18n.T("messages.item_purchased", map[string]interface{}{"item": "{item}"}),
+			"item_ready":             i18n.T("messages.item_ready", map[string]interface{}{"item": "{item}"}),
+			"ultimate_ready":         i18n.T("messages.ultimate_ready", nil),
+			"ability_ready":          i18n.T("messages.ability_ready", map[string]interface{}{"ability": "{ability}"}),
+			"hero_health_low":        i18n.T("messages.hero_health_low", nil),
+			"hero_health_critical":   i18n.T("messages.hero_health_critical", nil),
+			"hero_mana_low":          i18n.T("messages.hero_mana_low", nil),
+			"hero_death":             i18n.T("messages.hero_death", map[string]interface{}{"respawn_seconds": "{respawn_seconds}"}),
+			"hero_death_buyback":     i18n.T("messages.hero_death_buyback", map[string]interface{}{"respawn_seconds": "{respawn_seconds}"}),
+			"hero_respawn_countdown": i18n.T("messages.hero_respawn_countdown", map[string]interface{}{"seconds": "{seconds}"}),
+			"hero_respawned":         i18n.T("messages.hero_respawned", nil),
+			"status_smoked":          i18n.T("messages.status_smoked", nil),
+			"smoke_broken":           i18n.T("messages.smoke_broken", nil),
+			"status_silenced":        i18n.T("messages.status_silenced", nil),
+			"status_stunned":         i18n.T("messages.status_stunned", nil),
+			"status_hexed":           i18n.T("messages.status_hexed", nil),
+			"status_broken":          i18n.T("messages.status_broken", nil),
+			"status_magic_immune":    i18n.T("messages.status_magic_immune", nil),
+			"magic_immune_ended":     i18n.T("messages.magic_immune_ended", nil),
+			"status_muted":           i18n.T("messages.status_muted", nil),
+			"status_disarmed":        i18n.T("messages.status_disarmed", nil),
+			"draft_pick_timer":       i18n.T("messages.draft_pick_timer", map[string]interface{}{"seconds": "{seconds}"}),
+			"draft_reserve_time":     i18n.T("messages.draft_reserve_time", map[string]interface{}{"seconds": "{seconds}"}),
+			"strategy_time":          i18n.T("messages.strategy_time", nil),
+			"tormentor_spawn":        i18n.T("messages.tormentor_spawn", map[string]interface{}{"seconds": "{seconds}"}),
+			"tormentor_respawn":      i18n.T("messages.tormentor_respawn", map[string]interface{}{"seconds": "{seconds}"}),
+			"game_paused":            i18n.T("messages.game_paused", nil),
+			"game_unpaused":          i18n.T("messages.game_unpaused", nil),
 		},
 		System: &SystemConfig{
 			FirstRun:     DefaultFirstRun,
 			GSIInstalled: DefaultGSIInstalled,
 		},
+		Recording: &RecordingConfig{
+			Enabled: DefaultRecordingEnabled,
+		},
+		Items: &ItemsConfig{
+			WatchList: []string{"item_black_king_bar", "item_blink", "item_hand_of_midas"},
+		},
+		Abilities: &AbilitiesConfig{
+			WatchList: map[string][]string{},
+		},
 		Voice: map[string]interface{}{
 			"apiKey":       "",
 			"voiceId":      DefaultVoiceID,
@@ -163,10 +322,324 @@ func DefaultGameConfig() *GameConfig {
 				Description:    "Alertas de mudança dia/noite para timing estratégico",
 				Category:       "timing",
 			},
+			"roshan": {
+				Enabled:        true,
+				WarningSeconds: DefaultRoshanWarning,
+				Min:            10,
+				Max:            120,
+				Step:           5,
+				Name:           "Roshan",
+				Description:    "Janelas de respawn (8-11min após a morte), Aegis e drops do próximo Roshan",
+				Category:       "objective",
+			},
+			"tormentor": {
+				Enabled:        true,
+				WarningSeconds: DefaultTormentorWarning,
+				Min:            10,
+				Max:            120,
+				Step:           5,
+				Name:           "Tormentor",
+				Description:    "Primeiro spawn (20:00) e respawn 10min após cada morte",
+				Category:       "objective",
+			},
+			"glyph": {
+				Enabled:     true,
+				Name:        "Glyph",
+				Description: "Glyph do seu time disponível. O campo \"enemy\" avisa também o glyph inimigo (precisa do time do jogador; sem time, como espectador, avisa o glyph dos dois lados)",
+				Category:    "objective",
+			},
+			"lotus": {
+				Enabled:        true,
+				WarningSeconds: DefaultLotusWarning,
+				Min:            5,
+				Max:            60,
+				Step:           5,
+				Name:           "Lótus de Cura",
+				Description:    "Crescimento das Lótus nas piscinas (3:00, depois a cada 3min)",
+				Category:       "timing",
+			},
+			"outpost": {
+				Enabled:        true,
+				WarningSeconds: DefaultOutpostWarning,
+				Min:            5,
+				Max:            60,
+				Step:           5,
+				Name:           "XP do Posto Avançado",
+				Description:    "XP dos postos avançados (10:00, depois a cada 10min)",
+				Category:       "timing",
+			},
+			"draft_pick_timer": {
+				Enabled:        true,
+				WarningSeconds: DefaultDraftPickWarning,
+				Min:            5,
+				Max:            30,
+				Step:           5,
+				Name:           "Tempo de Escolha",
+				Description:    "Aviso quando o tempo do seu time para escolher ou banir está acabando",
+				Category:       "draft",
+			},
+			"draft_reserve_time": {
+				Enabled:        true,
+				WarningSeconds: DefaultDraftReserveWarning,
+				Min:            10,
+				Max:            90,
+				Step:           5,
+				Name:           "Tempo Reserva",
+				Description:    "Aviso quando o tempo reserva do seu time está acabando",
+				Category:       "draft",
+			},
+			"strategy_time": {
+				Enabled:     true,
+				Name:        "Tempo de Estratégia",
+				Description: "Anuncia o fim do draft e o início do tempo de estratégia",
+				Category:    "draft",
+			},
+			"hero_health_low": {
+				Enabled:        true,
+				WarningSeconds: DefaultHealthLowThreshold,
+				Min:            5,
+				Max:            50,
+				Step:           5,
+				Name:           "Vida Baixa",
+				Description:    "Aviso quando a vida do herói cai abaixo da porcentagem",
+				Category:       "hero",
+				Field:          "threshold",
+			},
+			"hero_health_critical": {
+				Enabled:        true,
+				WarningSeconds: DefaultHealthCriticalThreshold,
+				Min:            5,
+				Max:            50,
+				Step:           5,
+				Name:           "Vida Crítica",
+				Description:    "Aviso urgente quando a vida do herói fica crítica",
+				Category:       "hero",
+				Field:          "threshold",
+			},
+			"hero_mana_low": {
+				Enabled:        true,
+				WarningSeconds: DefaultManaLowThreshold,
+				Min:            5,
+				Max:            50,
+				Step:           5,
+				Name:           "Mana Baixa",
+				Description:    "Aviso quando a mana do herói cai abaixo da porcentagem",
+				Category:       "hero",
+				Field:          "threshold",
+			},
+			"hero_death": {
+				Enabled:     true,
+				Name:        "Morte",
+				Description: "Anuncia cada morte do seu herói, o tempo de respawn e se há buyback",
+				Category:    "hero",
+			},
+			"hero_respawn_countdown": {
+				Enabled:        false,
+				WarningSeconds: DefaultRespawnCountdown,
+				Min:            1,
+				Max:            10,
+				Step:           1,
+				Name:           "Contagem do Respawn",
+				Description:    "Conta em voz alta os últimos segundos até o respawn",
+				Category:       "hero",
+			},
+			"hero_respawned": {
+				Enabled:     true,
+				Name:        "Respawn",
+				Description: "Avisa quando seu herói volta à vida",
+				Category:    "hero",
+			},
+			"hero_level_up": {
+				Enabled:     false,
+				Name:        "Level Up",
+				Description: "Anuncia cada nível ganho",
+				Category:    "hero",
+			},
+			"game_state_change": {
+				Enabled:     false,
+				Name:        "Estado da Partida",
+				Description: "Início do draft, da partida e fim de jogo",
+				Category:    "map",
+			},
+			"day_night_change": {
+				Enabled:     false,
+				Name:        "Dia/Noite",
+				Description: "Anuncia a virada dia/noite no momento em que acontece",
+				Category:    "map",
+			},
+			"score_change": {
+				Enabled:     false,
+				Name:        "Placar",
+				Description: "Anuncia quem marcou cada abate",
+				Category:    "map",
+			},
+			"status_smoked": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Smoke",
+				Description:    "Aviso quando você fica sob Smoke of Deceit",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"smoke_broken": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Smoke Quebrado",
+				Description:    "Aviso quando o smoke acaba ou é revelado",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_silenced": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Silenciado",
+				Description:    "Aviso quando você é silenciado",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_stunned": {
+				Enabled:        false,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Atordoado",
+				Description:    "Aviso quando você é atordoado",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_hexed": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Hex",
+				Description:    "Aviso quando você é transformado por um hex",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_broken": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Break",
+				Description:    "Aviso quando suas passivas são desativadas por break",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_magic_immune": {
+				Enabled:        false,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Imunidade Mágica",
+				Description:    "Aviso quando você fica imune a magia",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"magic_immune_ended": {
+				Enabled:        false,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Fim da Imunidade",
+				Description:    "Aviso quando a imunidade mágica acaba",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_muted": {
+				Enabled:        true,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Mudo",
+				Description:    "Aviso quando seus itens são desativados (mute)",
+				Category:       "status",
+				Field:          "throttle",
+			},
+			"status_disarmed": {
+				Enabled:        false,
+				WarningSeconds: DefaultStatusThrottle,
+				Min:            0,
+				Max:            30,
+				Step:           1,
+				Name:           "Desarmado",
+				Description:    "Aviso quando você não pode atacar",
+				Category:       "status",
+				Field:          "throttle",
+			},
 		},
 	}
 }
 
+// FillDefaults adds timings, timing fields, messages and event metadata that
+// are missing from a config written by an older version. User values are
+// never overwritten.
+func (gc *GameConfig) FillDefaults() {
+	defaults := DefaultGameConfig()
+
+	if gc.Timings == nil {
+		gc.Timings = make(map[string]map[string]interface{})
+	}
+	for key, fields := range defaults.Timings {
+		timing, exists := gc.Timings[key]
+		if !exists || timing == nil {
+			timing = make(map[string]interface{})
+			gc.Timings[key] = timing
+		}
+		for field, value := range fields {
+			if _, exists := timing[field]; !exists {
+				timing[field] = value
+			}
+		}
+	}
+
+	if gc.Messages == nil {
+		gc.Messages = make(map[string]string)
+	}
+	for key, message := range defaults.Messages {
+		// Skip messages that couldn't be translated yet (i18n not initialized)
+		if strings.HasPrefix(message, "messages.") {
+			continue
+		}
+		if _, exists := gc.Messages[key]; !exists {
+			gc.Messages[key] = message
+		}
+	}
+
+	if gc.Items == nil {
+		gc.Items = defaults.Items
+	}
+
+	if gc.Abilities == nil {
+		gc.Abilities = defaults.Abilities
+	}
+
+	if gc.Events == nil {
+		gc.Events = make(map[string]TimingEvent)
+	}
+	for key, event := range defaults.Events {
+		if _, exists := gc.Events[key]; !exists {
+			gc.Events[key] = event
+		}
+	}
+}
+
 // EnsureConfigExists creates the config file with defaults if it doesn't exist
 func EnsureConfigExists() error {
 	configPath, err := GetConfigPath()
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"os"
+	"sync"
 )
 
 // ============================================================================
@@ -13,6 +14,10 @@ import (
 // - Audio settings (cache path, voice speed)
 // - Custom messages for events
 // - System settings (first run, GSI installed)
+// - Tick recording settings
+// - Item cooldown watch list
+// - Per-hero ability watch lists
+// - User-defined timers and alert rules
 
 // TimingEvent represents a complete timing event configuration
 type TimingEvent struct {
@@ -23,7 +28,8 @@ type TimingEvent struct {
 	Step           int    `json:"step"`
 	Name           string `json:"name"`
 	Description    string `json:"description"`
-	Category       string `json:"category"` // "rune" or "timing"
+	Category       string `json:"category"`        // "rune", "timing", "objective", "hero", "status", "draft", "map", "custom" or "rule"
+	Field          string `json:"field,omitempty"` // Timing field edited by WarningSeconds (default "warning_seconds")
 }
 
 // GameConfig holds the game configuration
@@ -37,12 +43,36 @@ type GameConfig struct {
 	System     *SystemConfig                     `json:"system,omitempty"`
 	Voice      map[string]interface{}            `json:"voice,omitempty"`
 	Events     map[string]TimingEvent            `json:"events,omitempty"` // Complete event metadata
+	Recording  *RecordingConfig                  `json:"recording,omitempty"`
+	Items      *ItemsConfig                      `json:"items,omitempty"`
+	Abilities  *AbilitiesConfig                  `json:"abilities,omitempty"`
+	Schedule   string                            `json:"schedule,omitempty"` // Timing schedule patch ("auto" = from provider.version)
+	Timers     []CustomTimer                     `json:"timers,omitempty"`   // User-defined timers
+	Rules      []AlertRule                       `json:"rules,omitempty"`    // User-defined alert rules
+
+	mu sync.RWMutex // Consumers read the config on every tick while endpoints edit it
 }
 
 // SystemConfig holds system configuration
 type SystemConfig struct {
-	FirstRun     bool `json:"first_run"`
-	GSIInstalled bool `json:"gsi_installed"`
+	FirstRun     bool   `json:"first_run"`
+	GSIInstalled bool   `json:"gsi_installed"`
+	GSIToken     string `json:"-"` // Shared secret written into the GSI cfg auth block (kept in its own file, see EnsureGSIToken)
+}
+
+// RecordingConfig holds raw GSI tick recording configuration
+type RecordingConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ItemsConfig holds item alert configuration
+type ItemsConfig struct {
+	WatchList []string `json:"watch_list"` // Items announced when they come off cooldown (e.g. "item_black_king_bar")
+}
+
+// AbilitiesConfig holds ability alert configuration
+type AbilitiesConfig struct {
+	WatchList map[string][]string `json:"watch_list"` // Hero ("npc_dota_hero_axe") -> abilities announced off cooldown
 }
 
 // AudioConfig holds audio configuration
@@ -64,20 +94,53 @@ func LoadGameConfig(path string) (*GameConfig, error) {
 	if err := decoder.Decode(&cfg); err != nil {
 		return nil, err
 	}
+
+	// Configs from older versions miss newer timings and messages
+	cfg.FillDefaults()
+
 	return &cfg, nil
 }
 
-// GetTimingConfig returns timing configuration for a specific event
+// Update runs fn with the config locked for writing. Code that changes fields
+// directly must do it inside fn (without calling other GameConfig methods).
+func (gc *GameConfig) Update(fn func()) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	fn()
+}
+
+// View runs fn with the config locked for reading (same rules as Update)
+func (gc *GameConfig) View(fn func()) {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	fn()
+}
+
+// GetTimingConfig returns a copy of the timing configuration for a specific event
 func (gc *GameConfig) GetTimingConfig(eventType string) map[string]interface{} {
-	if gc.Timings == nil {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.timingConfig(eventType)
+}
+
+// timingConfig copies a timing (caller holds mu)
+func (gc *GameConfig) timingConfig(eventType string) map[string]interface{} {
+	timing := gc.Timings[eventType]
+	if timing == nil {
 		return nil
 	}
-	return gc.Timings[eventType]
+	copied := make(map[string]interface{}, len(timing))
+	for key, value := range timing {
+		copied[key] = value
+	}
+	return copied
 }
 
 // SaveGameConfig saves game configuration to file
 func SaveGameConfig(path string, cfg *GameConfig) error {
+	cfg.mu.RLock()
 	data, err := json.MarshalIndent(cfg, "", "  ")
+	cfg.mu.RUnlock()
 	if err != nil {
 		return err
 	}
@@ -86,7 +149,10 @@ func SaveGameConfig(path string, cfg *GameConfig) error {
 
 // IsTimingEnabled checks if a timing event is enabled
 func (gc *GameConfig) IsTimingEnabled(eventType string) bool {
-	cfg := gc.GetTimingConfig(eventType)
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	cfg := gc.Timings[eventType]
 	if cfg == nil {
 		return false
 	}
@@ -102,8 +168,62 @@ func (gc *GameConfig) IsTimingEnabled(eventType string) bool {
 	return true
 }
 
+// IsRecordingEnabled checks if raw GSI ticks should be recorded to disk
+func (gc *GameConfig) IsRecordingEnabled() bool {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.Recording != nil && gc.Recording.Enabled
+}
+
+// GetItemWatchList returns a copy of the items announced when they come off cooldown
+func (gc *GameConfig) GetItemWatchList() []string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	if gc.Items == nil {
+		return nil
+	}
+	return append([]string(nil), gc.Items.WatchList...)
+}
+
+// GetWatchedAbilities returns a copy of the abilities announced off cooldown for a hero
+func (gc *GameConfig) GetWatchedAbilities(hero string) []string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	if gc.Abilities == nil {
+		return nil
+	}
+	return append([]string(nil), gc.Abilities.WatchList[hero]...)
+}
+
+// GetAbilityWatchLists returns a copy of the watched abilities of every hero
+func (gc *GameConfig) GetAbilityWatchLists() map[string][]string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	watchLists := make(map[string][]string)
+	if gc.Abilities == nil {
+		return watchLists
+	}
+	for hero, abilities := range gc.Abilities.WatchList {
+		watchLists[hero] = append([]string(nil), abilities...)
+	}
+	return watchLists
+}
+
+// GetSchedulePatch returns the timing schedule patch ("auto" when not set)
+func (gc *GameConfig) GetSchedulePatch() string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	if gc.Schedule == "" {
+		return DefaultSchedule
+	}
+	return gc.Schedule
+}
+
 // GetMessage returns the message template for an event
 func (gc *GameConfig) GetMessage(eventType string) string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
 	if msg, exists := gc.Messages[eventType]; exists {
 		return msg
 	}
@@ -97,6 +97,15 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(appDir, "config.json"), nil
 }
 
+// GetGSITokenPath returns the full path to the GSI auth token file
+func GetGSITokenPath() (string, error) {
+	appDir, err := GetAppDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "gsi_token"), nil
+}
+
 // GetVoiceCachePath returns the full path to the voice cache directory
 func GetVoiceCachePath() (string, error) {
 	cacheDir, err := GetCacheDir()
@@ -128,3 +137,19 @@ func GetLogPath() (string, error) {
 	
 	return filepath.Join(logDir, "runinhas.log"), nil
 }
+
+// GetRecordingsDir returns the directory where raw GSI tick recordings are stored
+func GetRecordingsDir() (string, error) {
+	appDir, err := GetAppDataDir()
+	if err != nil {
+		return "", err
+	}
+	recordingsDir := filepath.Join(appDir, "recordings")
+	
+	// Create recordings directory if it doesn't exist
+	if err := os.MkdirAll(recordingsDir, 0755); err != nil {
+		return "", err
+	}
+	
+	return recordingsDir, nil
+}
@@ -0,0 +1,206 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// heroNamePrefix is the prefix of hero.name in GSI ("npc_dota_hero_axe")
+const heroNamePrefix = "npc_dota_hero_"
+
+// abilityState is what the consumer remembers of an ability between ticks
+type abilityState struct {
+	level    int64
+	cooldown int64
+}
+
+// AbilitiesConsumer reads the abilities.ability* blocks and announces the
+// ultimate and watched abilities when their cooldown really finishes. The
+// abilities to watch are configured per hero.
+type AbilitiesConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu        sync.Mutex              // Guards per-match state (Reset runs on the session goroutine)
+	hero      string                  // Hero the state below belongs to
+	abilities map[string]abilityState // Previous tick, by ability name
+}
+
+// NewAbilitiesConsumer creates a new abilities consumer
+func NewAbilitiesConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *AbilitiesConsumer {
+	return &AbilitiesConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "abilities", Policy: events.DropOldest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+	}
+}
+
+// Start begins consuming events
+func (ac *AbilitiesConsumer) Start() {
+	go ac.consume()
+	ac.logger.Info("⚡ AbilitiesConsumer started")
+}
+
+// Stop stops the consumer
+func (ac *AbilitiesConsumer) Stop() {
+	close(ac.stopChan)
+	ac.eventBus.Unsubscribe(ac.eventChan)
+	ac.logger.Info("⚡ AbilitiesConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (ac *AbilitiesConsumer) Reset() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.hero = ""
+	ac.abilities = nil
+}
+
+// consume processes TickEvents
+func (ac *AbilitiesConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-ac.eventChan:
+			if !ok {
+				return
+			}
+			ac.processAbilities(event)
+		case <-ac.stopChan:
+			return
+		}
+	}
+}
+
+// processAbilities compares every ability with the previous tick
+func (ac *AbilitiesConsumer) processAbilities(event events.TickEvent) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	if ac.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if len(state.Abilities) == 0 || state.Hero.Name == "" {
+		return
+	}
+
+	// A different hero (e.g. after a reconnect) starts from a clean baseline
+	if state.Hero.Name != ac.hero {
+		ac.hero = state.Hero.Name
+		ac.abilities = nil
+	}
+
+	watched := make(map[string]bool)
+	for _, name := range ac.getWatchedAbilities(state.Hero.Name) {
+		watched[name] = true
+	}
+
+	current := make(map[string]abilityState, len(state.Abilities))
+	for _, ability := range state.Abilities {
+		if ability.Name == "" || ability.Passive {
+			continue
+		}
+		current[ability.Name] = abilityState{level: ability.Level, cooldown: ability.Cooldown}
+
+		// First tick only sets the baseline
+		previous, known := ac.abilities[ability.Name]
+		if ac.abilities == nil || !known || ability.Level == 0 {
+			continue
+		}
+
+		learned := previous.level == 0 // Freshly learned abilities are ready
+		cooledDown := previous.cooldown > 0 && ability.Cooldown == 0
+		if !learned && !cooledDown {
+			continue
+		}
+
+		data := map[string]interface{}{
+			"ability":      abilityDisplayName(ability.Name, state.Hero.Name),
+			"ability_name": ability.Name,
+			"level":        ability.Level,
+			"can_cast":     ability.CanCast, // false when there's not enough mana
+			"learned":      learned,
+		}
+
+		switch {
+		case ability.Ultimate:
+			if ac.isEventEnabled(handlers.EventUltimateReady) {
+				ac.handleEvent(handlers.EventUltimateReady, data)
+			}
+		case watched[ability.Name] && cooledDown:
+			if ac.isEventEnabled(handlers.EventAbilityReady) {
+				ac.handleEvent(handlers.EventAbilityReady, data)
+			}
+		}
+	}
+
+	ac.abilities = current
+}
+
+// getWatchedAbilities returns the abilities watched for a hero
+func (ac *AbilitiesConsumer) getWatchedAbilities(hero string) []string {
+	if ac.gameConfig == nil {
+		return nil
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetWatchedAbilities(string) []string
+	}
+
+	if gc, ok := ac.gameConfig.(GameConfigInterface); ok {
+		return gc.GetWatchedAbilities(hero)
+	}
+
+	return nil
+}
+
+// abilityDisplayName turns an ability name into something speakable
+// ("tidehunter_ravage" -> "ravage")
+func abilityDisplayName(ability, hero string) string {
+	name := strings.TrimPrefix(ability, strings.TrimPrefix(hero, heroNamePrefix)+"_")
+	return strings.ReplaceAll(name, "_", " ")
+}
+
+// isEventEnabled checks if an ability alert is enabled
+func (ac *AbilitiesConsumer) isEventEnabled(eventType string) bool {
+	if ac.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := ac.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (ac *AbilitiesConsumer) handleEvent(eventType string, data interface{}) {
+	ac.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("⚡ Ability event triggered")
+
+	for _, handler := range ac.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,237 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Buyback events
+const (
+	EventBuybackReady     = "buyback_ready"     // Buyback came off cooldown
+	EventBuybackGoldLow   = "buyback_gold_low"  // Gold dropped below the buyback cost
+	EventBuybackAvailable = "buyback_available" // Gold covers the buyback cost again
+)
+
+// buybackAlertGap keeps the same alert from repeating every tick (game
+// seconds). A change to the other gold state is always announced.
+const buybackAlertGap int64 = 30
+
+// BuybackConsumer watches player.gold, hero.buyback_cost and
+// hero.buyback_cooldown and announces buyback cooldown and gold changes from
+// the minute in the buyback timing ("time")
+type BuybackConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu          sync.Mutex       // Guards per-match state (Reset runs on the session goroutine)
+	tracking    bool             // Start minute reached and the baseline below is set
+	canAfford   bool             // Gold covered the buyback cost on the last tick
+	onCooldown  bool             // Buyback was on cooldown on the last tick
+	lastAlerted map[string]int64 // Clock of the last alert per event type
+	goldAlert   string           // Last gold alert sent (EventBuybackGoldLow or EventBuybackAvailable)
+}
+
+// NewBuybackConsumer creates a new buyback consumer
+func NewBuybackConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *BuybackConsumer {
+	return &BuybackConsumer{
+		logger:      logger,
+		eventBus:    eventBus,
+		eventChan:   eventBus.SubscribeWith(events.SubscribeOptions{Name: "buyback", Policy: events.KeepLatest}),
+		stopChan:    make(chan struct{}),
+		handlers:    handlerList,
+		gameConfig:  gameConfig,
+		lastAlerted: make(map[string]int64),
+	}
+}
+
+// Start begins consuming events
+func (bc *BuybackConsumer) Start() {
+	go bc.consume()
+	bc.logger.Info("💰 BuybackConsumer started")
+}
+
+// Stop stops the consumer
+func (bc *BuybackConsumer) Stop() {
+	close(bc.stopChan)
+	bc.eventBus.Unsubscribe(bc.eventChan)
+	bc.logger.Info("💰 BuybackConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (bc *BuybackConsumer) Reset() {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.tracking = false
+	bc.canAfford = false
+	bc.onCooldown = false
+	bc.lastAlerted = make(map[string]int64)
+	bc.goldAlert = ""
+}
+
+// consume processes TickEvents
+func (bc *BuybackConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-bc.eventChan:
+			if !ok {
+				return
+			}
+			bc.processBuyback(event)
+		case <-bc.stopChan:
+			return
+		}
+	}
+}
+
+// processBuyback compares gold and cooldown with the previous tick
+func (bc *BuybackConsumer) processBuyback(event events.TickEvent) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if bc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	clockTime := state.Map.ClockTime
+	if !state.Map.InProgress() || state.Map.Paused {
+		return
+	}
+
+	// No hero block (spectating, or hero not picked yet)
+	if state.Hero.BuybackCost <= 0 || !bc.isEventEnabled() {
+		return
+	}
+
+	if clockTime < bc.getStartMinute()*MinuteInSeconds {
+		bc.tracking = false
+		return
+	}
+
+	gold := state.Player.Gold
+	cost := state.Hero.BuybackCost
+	canAfford := gold >= cost
+	onCooldown := state.Hero.BuybackCooldown > 0
+
+	// First tick after the start minute only sets the baseline
+	if !bc.tracking {
+		bc.tracking = true
+		bc.canAfford = canAfford
+		bc.onCooldown = onCooldown
+		return
+	}
+
+	missing := cost - gold
+	if missing < 0 {
+		missing = 0
+	}
+
+	data := map[string]interface{}{
+		"gold":       gold,
+		"cost":       cost,
+		"missing":    missing, // Gold still needed for buyback
+		"cooldown":   state.Hero.BuybackCooldown,
+		"clock_time": clockTime,
+	}
+
+	if bc.onCooldown && !onCooldown {
+		bc.alert(EventBuybackReady, clockTime, data)
+	}
+
+	// Gold changes only matter while buyback is off cooldown
+	if !onCooldown {
+		switch {
+		case bc.canAfford && !canAfford:
+			bc.alertGold(EventBuybackGoldLow, clockTime, data)
+		case !bc.canAfford && canAfford:
+			bc.alertGold(EventBuybackAvailable, clockTime, data)
+		}
+	}
+
+	bc.canAfford = canAfford
+	bc.onCooldown = onCooldown
+}
+
+// alert sends an event unless the same one went out less than buybackAlertGap ago
+func (bc *BuybackConsumer) alert(eventType string, clockTime int64, data map[string]interface{}) {
+	if last, exists := bc.lastAlerted[eventType]; exists && clockTime-last < buybackAlertGap {
+		return
+	}
+
+	bc.handleEvent(eventType, data)
+	bc.lastAlerted[eventType] = clockTime
+}
+
+// alertGold sends a gold alert. Only a repeat of the last gold alert is
+// throttled; switching between low and available always goes out.
+func (bc *BuybackConsumer) alertGold(eventType string, clockTime int64, data map[string]interface{}) {
+	if bc.goldAlert != eventType {
+		delete(bc.lastAlerted, eventType)
+	}
+
+	bc.alert(eventType, clockTime, data)
+	bc.goldAlert = eventType
+}
+
+// getStartMinute returns the game minute buyback warnings start at
+func (bc *BuybackConsumer) getStartMinute() int64 {
+	if bc.gameConfig == nil {
+		return config.DefaultBuybackStartMinute
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := bc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig("buyback"); cfg != nil {
+			if val, exists := cfg["time"]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return config.DefaultBuybackStartMinute
+}
+
+// isEventEnabled checks if the buyback timing is enabled
+func (bc *BuybackConsumer) isEventEnabled() bool {
+	if bc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := bc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled("buyback")
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (bc *BuybackConsumer) handleEvent(eventType string, data interface{}) {
+	bc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("💰 Buyback event triggered")
+
+	for _, handler := range bc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,228 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Draft events
+const (
+	EventDraftPickTimer   = "draft_pick_timer"   // Our pick/ban timer is running low
+	EventDraftReserveTime = "draft_reserve_time" // Our reserve (bonus) time is running low
+	EventStrategyTime     = "strategy_time"      // Hero selection ended, strategy time started
+)
+
+// DraftConsumer reads the draft block during hero selection and warns when
+// our pick timer or reserve time runs low, then announces strategy time
+type DraftConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu            sync.Mutex // Guards per-match state (Reset runs on the session goroutine)
+	lastGameState string     // map.game_state on the previous tick
+	alertedTurn   string     // Turn whose pick timer was already announced
+	lastReserve   int64      // Our reserve time on the previous tick (-1 = unknown)
+}
+
+// NewDraftConsumer creates a new draft consumer
+func NewDraftConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *DraftConsumer {
+	return &DraftConsumer{
+		logger:      logger,
+		eventBus:    eventBus,
+		eventChan:   eventBus.SubscribeWith(events.SubscribeOptions{Name: "draft", Policy: events.KeepLatest}),
+		stopChan:    make(chan struct{}),
+		handlers:    handlerList,
+		gameConfig:  gameConfig,
+		lastReserve: -1,
+	}
+}
+
+// Start begins consuming events
+func (dc *DraftConsumer) Start() {
+	go dc.consume()
+	dc.logger.Info("📋 DraftConsumer started")
+}
+
+// Stop stops the consumer
+func (dc *DraftConsumer) Stop() {
+	close(dc.stopChan)
+	dc.eventBus.Unsubscribe(dc.eventChan)
+	dc.logger.Info("📋 DraftConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (dc *DraftConsumer) Reset() {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	dc.lastGameState = ""
+	dc.alertedTurn = ""
+	dc.lastReserve = -1
+}
+
+// consume processes TickEvents
+func (dc *DraftConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-dc.eventChan:
+			if !ok {
+				return
+			}
+			dc.processDraft(event)
+		case <-dc.stopChan:
+			return
+		}
+	}
+}
+
+// processDraft checks the draft timers and the end of hero selection
+func (dc *DraftConsumer) processDraft(event events.TickEvent) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	gameState := state.Map.GameState
+	if gameState == "" {
+		return
+	}
+
+	previousState := dc.lastGameState
+	dc.lastGameState = gameState
+
+	if gameState == events.GameStateStrategyTime && previousState == events.GameStateHeroSelection {
+		if dc.isEventEnabled(EventStrategyTime) {
+			dc.handleEvent(EventStrategyTime, map[string]interface{}{})
+		}
+		return
+	}
+
+	if gameState != events.GameStateHeroSelection {
+		return
+	}
+
+	team := draftTeamID(state)
+	draft := state.Draft
+	if team == 0 || draft.ActiveTeam != team {
+		return // Not our turn (or we can't tell which team is ours)
+	}
+
+	// Pick timer: once per turn, identified by how many picks/bans were made
+	remaining := draft.ActiveTeamTimeRemaining
+	turn := fmt.Sprintf("%d-%d", team, draftSelections(draft))
+	pickWarning := dc.getWarningSeconds(EventDraftPickTimer, config.DefaultDraftPickWarning)
+	if remaining > 0 && remaining <= pickWarning && dc.alertedTurn != turn {
+		dc.alertedTurn = turn
+		if dc.isEventEnabled(EventDraftPickTimer) {
+			dc.handleEvent(EventDraftPickTimer, map[string]interface{}{
+				"seconds": remaining,
+				"pick":    draft.Pick, // false while banning
+			})
+		}
+	}
+
+	// Reserve time is only spent once the pick timer is over
+	reserve := draft.RadiantBonusTime
+	if team == 3 {
+		reserve = draft.DireBonusTime
+	}
+	reserveWarning := dc.getWarningSeconds(EventDraftReserveTime, config.DefaultDraftReserveWarning)
+	if remaining == 0 && reserve <= reserveWarning && dc.lastReserve > reserveWarning {
+		if dc.isEventEnabled(EventDraftReserveTime) {
+			dc.handleEvent(EventDraftReserveTime, map[string]interface{}{
+				"seconds": reserve,
+			})
+		}
+	}
+	dc.lastReserve = reserve
+}
+
+// draftTeamID returns our draft team (2 = radiant, 3 = dire, 0 = unknown)
+func draftTeamID(state *events.GameState) int64 {
+	switch state.Player.TeamName {
+	case "radiant":
+		return 2
+	case "dire":
+		return 3
+	}
+
+	// Fall back to the draft's home team flag
+	if state.Draft.Team2.HomeTeam {
+		return 2
+	}
+	if state.Draft.Team3.HomeTeam {
+		return 3
+	}
+	return 0
+}
+
+// draftSelections counts the picks and bans made so far
+func draftSelections(draft events.Draft) int {
+	return len(draft.Team2.Picks) + len(draft.Team2.Bans) + len(draft.Team3.Picks) + len(draft.Team3.Bans)
+}
+
+// getWarningSeconds returns the warning_seconds of a draft timing
+func (dc *DraftConsumer) getWarningSeconds(eventType string, fallback int64) int64 {
+	if dc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := dc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig(eventType); cfg != nil {
+			if val, exists := cfg["warning_seconds"]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if a draft alert is enabled
+func (dc *DraftConsumer) isEventEnabled(eventType string) bool {
+	if dc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := dc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (dc *DraftConsumer) handleEvent(eventType string, data interface{}) {
+	dc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("📋 Draft event triggered")
+
+	for _, handler := range dc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,103 @@
+package consumers
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxExtrapolation is how long the clock keeps running without ticks
+// (past that we assume Dota disconnected and stop estimating)
+const MaxExtrapolation = 3 * time.Second
+
+// GameClock estimates the match clock between ticks. GSI only reports whole
+// seconds and ticks arrive late (cfg buffer/throttle) or not at all, so the
+// clock is anchored to the earliest wall time each second was seen and
+// extrapolated from there.
+type GameClock struct {
+	mu       sync.Mutex
+	reported int64         // Last map.clock_time
+	zero     time.Time     // Estimated wall time of clock_time 0
+	running  bool          // Clock ticking (in progress, not paused)
+	lastSeen time.Time     // Wall time of the last tick
+	drift    time.Duration // Estimate minus reported clock at the last tick
+}
+
+// Observe feeds a tick's clock_time. Returns true when the estimate had to be
+// rebased (first tick, resume, or the game clock fell behind the wall clock).
+func (gc *GameClock) Observe(clock int64, running bool, at time.Time) bool {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	wasRunning := gc.running
+	gc.reported = clock
+	gc.lastSeen = at
+	gc.running = running
+	if !running {
+		return false
+	}
+
+	// Wall time of clock 0 if this second started right now
+	candidate := at.Add(-time.Duration(clock) * time.Second)
+	if !wasRunning || gc.zero.IsZero() {
+		gc.zero = candidate
+		gc.drift = 0
+		return true
+	}
+
+	gc.drift = candidate.Sub(gc.zero) // How far into the second the estimate is
+	switch {
+	case gc.drift < 0:
+		// Seen earlier in the second than we thought
+		gc.zero = candidate
+	case gc.drift >= time.Second:
+		// The estimate ran a whole second ahead: the game clock is slower (lag)
+		gc.zero = candidate
+		return true
+	}
+	return false
+}
+
+// Now returns the estimated clock at a wall time (false if unknown or stale)
+func (gc *GameClock) Now(at time.Time) (float64, bool) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	if gc.lastSeen.IsZero() || at.Sub(gc.lastSeen) > MaxExtrapolation {
+		return 0, false
+	}
+	if !gc.running {
+		return float64(gc.reported), true
+	}
+
+	// Never below the reported second, never too far past the last tick
+	estimate := at.Sub(gc.zero).Seconds()
+	lowest := float64(gc.reported)
+	highest := lowest + MaxExtrapolation.Seconds()
+	if estimate < lowest {
+		estimate = lowest
+	}
+	if estimate > highest {
+		estimate = highest
+	}
+	return estimate, true
+}
+
+// Drift returns how far the estimate was from the reported clock at the last tick
+func (gc *GameClock) Drift() time.Duration {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	return gc.drift
+}
+
+// Reset forgets the clock (new match)
+func (gc *GameClock) Reset() {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	gc.reported = 0
+	gc.zero = time.Time{}
+	gc.running = false
+	gc.lastSeen = time.Time{}
+	gc.drift = 0
+}
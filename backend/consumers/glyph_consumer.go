@@ -0,0 +1,197 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Glyph events
+const (
+	EventGlyphAvailable      = "glyph_available"
+	EventEnemyGlyphAvailable = "enemy_glyph_available"
+)
+
+// GlyphConsumer announces when a glyph comes off cooldown. Players get their
+// team's map.glyph_cooldown; spectator data has map.radiant_glyph_cooldown
+// and map.dire_glyph_cooldown, so the enemy glyph can be announced too
+// (the "enemy" field of the glyph timing). Without a player team there is
+// no "enemy", so both sides are announced by name. It uses the tick deltas,
+// so it keeps no shadow state of its own.
+type GlyphConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+}
+
+// NewGlyphConsumer creates a new glyph consumer
+func NewGlyphConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *GlyphConsumer {
+	return &GlyphConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "glyph", Policy: events.DropOldest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+	}
+}
+
+// Start begins consuming events
+func (gc *GlyphConsumer) Start() {
+	go gc.consume()
+	gc.logger.Info("🛡️ GlyphConsumer started")
+}
+
+// Stop stops the consumer
+func (gc *GlyphConsumer) Stop() {
+	close(gc.stopChan)
+	gc.eventBus.Unsubscribe(gc.eventChan)
+	gc.logger.Info("🛡️ GlyphConsumer stopped")
+}
+
+// consume processes TickEvents
+func (gc *GlyphConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-gc.eventChan:
+			if !ok {
+				return
+			}
+			gc.processGlyph(event)
+		case <-gc.stopChan:
+			return
+		}
+	}
+}
+
+// processGlyph checks the glyph cooldowns that changed this tick
+func (gc *GlyphConsumer) processGlyph(event events.TickEvent) {
+	state := event.State
+	if !state.Map.InProgress() || !gc.isEventEnabled() {
+		return
+	}
+
+	team := state.Player.TeamName
+	enemy := ""
+	switch team {
+	case "radiant":
+		enemy = "dire"
+	case "dire":
+		enemy = "radiant"
+	default:
+		// No player team (spectating, many replayed ticks): there is no "ours"
+		// or "enemy", so announce whichever side's glyph comes back
+		gc.processBothSides(state)
+		return
+	}
+
+	// Our glyph (player data has it without the team prefix)
+	ownPath := "map.glyph_cooldown"
+	if !state.Has(ownPath) && team != "" {
+		ownPath = "map." + team + "_glyph_cooldown"
+	}
+	if gc.cameOffCooldown(state, ownPath) {
+		gc.handleEvent(EventGlyphAvailable, map[string]interface{}{
+			"team":       team,
+			"clock_time": state.Map.ClockTime,
+		})
+	}
+
+	if enemy == "" || !gc.isEnemyEnabled() {
+		return
+	}
+	if gc.cameOffCooldown(state, "map."+enemy+"_glyph_cooldown") {
+		gc.handleEvent(EventEnemyGlyphAvailable, map[string]interface{}{
+			"team":       enemy,
+			"clock_time": state.Map.ClockTime,
+		})
+	}
+}
+
+// processBothSides announces the glyph of each side by team name
+func (gc *GlyphConsumer) processBothSides(state *events.GameState) {
+	if gc.cameOffCooldown(state, "map.glyph_cooldown") {
+		gc.handleEvent(EventGlyphAvailable, map[string]interface{}{
+			"team":       "",
+			"clock_time": state.Map.ClockTime,
+		})
+	}
+
+	for _, side := range []string{"radiant", "dire"} {
+		if gc.cameOffCooldown(state, "map."+side+"_glyph_cooldown") {
+			gc.handleEvent(EventGlyphAvailable, map[string]interface{}{
+				"team":       side,
+				"clock_time": state.Map.ClockTime,
+			})
+		}
+	}
+}
+
+// cameOffCooldown checks if the cooldown at path went from >0 to 0 this tick
+func (gc *GlyphConsumer) cameOffCooldown(state *events.GameState, path string) bool {
+	change, ok := state.Change(path)
+	if !ok || !change.Previous.Exists() || !change.Current.Exists() {
+		return false
+	}
+	return change.Previous.Int() > 0 && change.Current.Int() == 0
+}
+
+// isEventEnabled checks if the glyph timing is enabled
+func (gc *GlyphConsumer) isEventEnabled() bool {
+	if gc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if cfg, ok := gc.gameConfig.(GameConfigInterface); ok {
+		return cfg.IsTimingEnabled("glyph")
+	}
+
+	return true // Default to enabled
+}
+
+// isEnemyEnabled checks the "enemy" field of the glyph timing (off by default)
+func (gc *GlyphConsumer) isEnemyEnabled() bool {
+	if gc.gameConfig == nil {
+		return false
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if cfg, ok := gc.gameConfig.(GameConfigInterface); ok {
+		if timing := cfg.GetTimingConfig("glyph"); timing != nil {
+			// Booleans come from the config file, numbers from /api/timing
+			if enemy, ok := timing["enemy"].(bool); ok {
+				return enemy
+			}
+			if enemy, ok := toInt64Safe(timing["enemy"]); ok {
+				return enemy != 0
+			}
+		}
+	}
+
+	return false
+}
+
+// handleEvent sends event to all handlers
+func (gc *GlyphConsumer) handleEvent(eventType string, data interface{}) {
+	gc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("🛡️ Glyph event triggered")
+
+	for _, handler := range gc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -1,46 +1,57 @@
 package consumers
 
 import (
+	"dota-gsi/backend/config"
 	"dota-gsi/backend/events"
 	"dota-gsi/backend/handlers"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-// HeroConsumer processes hero-related events (deaths, health, mana, level)
+// HeroConsumer processes hero-related events (deaths, respawns, health, mana, level).
+// Thresholds, enable flags and throttles come from the hero_* timings;
+// ultimate cooldowns are tracked by AbilitiesConsumer.
 type HeroConsumer struct {
 	logger           *logrus.Entry
 	lastDeaths       int64
 	lastHealth       int64
 	lastMana         int64
 	lastLevel        int64
+	deathPending     bool  // Died, waiting for hero.respawn_seconds to announce it
+	dead             bool  // Hero was dead on the previous tick
+	lastCountdown    int64 // Last respawn second spoken by the countdown
+	eventBus         *events.EventBus
 	eventChan        <-chan events.TickEvent
 	stopChan         chan struct{}
 	handlers         []handlers.Handler
 	eventThrottle    map[string]time.Time // Throttle events to avoid spam
-	throttleConfig   map[string]time.Duration // Configurable throttle per event type
+	throttleConfig   map[string]time.Duration // Default throttle per event type (overridden by the "throttle" field)
+	gameConfig       interface{} // Game configuration (can be *config.GameConfig)
+	mu               sync.Mutex // Guards per-match state (Reset runs on the session goroutine)
 }
 
 // NewHeroConsumer creates a new hero consumer with handlers
-func NewHeroConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler) *HeroConsumer {
-	// Configurable throttle per event type for better control
+func NewHeroConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *HeroConsumer {
+	// Default throttle per event type (the "throttle" field of each timing overrides it)
 	throttleConfig := map[string]time.Duration{
-		"hero_health_low":     5 * time.Second,  // More responsive for critical events
-		"hero_health_critical": 3 * time.Second,  // Even faster for critical health
-		"hero_mana_low":       3 * time.Second,  // Quick mana warnings
+		"hero_health_low":      config.DefaultHealthLowThrottle * time.Second,      // More responsive for critical events
+		"hero_health_critical": config.DefaultHealthCriticalThrottle * time.Second, // Even faster for critical health
+		"hero_mana_low":        config.DefaultManaLowThrottle * time.Second,        // Quick mana warnings
 		"hero_death":          0,                // No throttle for death events
 		"hero_level_up":       0,                // No throttle for level up
-		"hero_ultimate_ready": 0,                // No throttle for ultimate
 	}
 
 	return &HeroConsumer{
 		logger:         logger,
-		eventChan:      eventBus.Subscribe(),
+		eventBus:       eventBus,
+		eventChan:      eventBus.SubscribeWith(events.SubscribeOptions{Name: "hero", Policy: events.DropNewest}),
 		stopChan:       make(chan struct{}),
 		handlers:       handlerList,
 		eventThrottle:  make(map[string]time.Time),
 		throttleConfig: throttleConfig,
+		gameConfig:     gameConfig,
 	}
 }
 
@@ -53,14 +64,33 @@ func (hc *HeroConsumer) Start() {
 // Stop stops the consumer
 func (hc *HeroConsumer) Stop() {
 	close(hc.stopChan)
+	hc.eventBus.Unsubscribe(hc.eventChan)
 	hc.logger.Info("🦸 HeroConsumer stopped")
 }
 
+// Reset clears per-match state (called by the session manager between matches)
+func (hc *HeroConsumer) Reset() {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	hc.lastDeaths = 0
+	hc.lastHealth = 0
+	hc.lastMana = 0
+	hc.lastLevel = 0
+	hc.deathPending = false
+	hc.dead = false
+	hc.lastCountdown = 0
+	hc.eventThrottle = make(map[string]time.Time)
+}
+
 // consume processes TickEvents and detects hero changes
 func (hc *HeroConsumer) consume() {
 	for {
 		select {
-		case event := <-hc.eventChan:
+		case event, ok := <-hc.eventChan:
+			if !ok {
+				return
+			}
 			hc.processHeroChanges(event)
 		case <-hc.stopChan:
 			return
@@ -70,32 +100,57 @@ func (hc *HeroConsumer) consume() {
 
 // processHeroChanges extracts hero data and detects changes
 func (hc *HeroConsumer) processHeroChanges(event events.TickEvent) {
-	// Use parsed event for efficient JSON access
-	parsed := events.NewParsedTickEvent(event)
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if hc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	// Use the shared state decoded once per tick
+	state := event.State
 
-	// Extract hero data using cached parse
-	deaths := parsed.GetInt64("player.deaths")
-	health := parsed.GetInt64("hero.health_percent")
-	mana := parsed.GetInt64("hero.mana_percent")
-	level := parsed.GetInt64("hero.level")
+	// Extract hero data
+	deaths := state.Player.Deaths
+	health := state.Hero.HealthPercent
+	mana := state.Hero.ManaPercent
+	level := state.Hero.Level
 
-	// Check for death events (deaths increased)
+	// Check for death events (deaths increased). The respawn time can arrive a
+	// tick after the death, so the announcement waits for it.
 	if deaths > hc.lastDeaths && hc.lastDeaths >= 0 {
+		hc.deathPending = true
+	}
+	if hc.deathPending && state.Hero.RespawnSeconds > 0 {
+		hc.deathPending = false
 		if hc.isEventEnabled("hero_death") {
 			hc.handleEvent("hero_death", map[string]interface{}{
-				"deaths":      deaths,
-				"prev_deaths": hc.lastDeaths,
-				"deaths_diff": deaths - hc.lastDeaths,
+				"deaths":            deaths,
+				"respawn_seconds":   state.Hero.RespawnSeconds,
+				"buyback_available": buybackAvailable(state),
 			})
 		}
 	}
 
-	// Use default thresholds
-	healthThreshold := int64(25)
-	manaThreshold := int64(15)
+	hc.processRespawn(state)
+
+	// Thresholds (health/mana percent) from config
+	healthThreshold := hc.getTimingValue("hero_health_low", "threshold", config.DefaultHealthLowThreshold)
+	criticalThreshold := hc.getTimingValue("hero_health_critical", "threshold", config.DefaultHealthCriticalThreshold)
+	manaThreshold := hc.getTimingValue("hero_mana_low", "threshold", config.DefaultManaLowThreshold)
 
-	// Check for low health
-	if health > 0 && health <= healthThreshold && hc.lastHealth > healthThreshold {
+	// Check for critical health first - a big hit can skip the low tier
+	crossedCritical := health > 0 && health <= criticalThreshold && hc.lastHealth > criticalThreshold
+	if crossedCritical && hc.isEventEnabled("hero_health_critical") {
+		if hc.canTriggerEvent("hero_health_critical") {
+			hc.handleEvent("hero_health_critical", map[string]interface{}{
+				"health":      health,
+				"prev_health": hc.lastHealth,
+				"value":       criticalThreshold,
+			})
+		}
+	} else if health > 0 && health <= healthThreshold && hc.lastHealth > healthThreshold {
+		// Check for low health
 		if hc.isEventEnabled("hero_health_low") && hc.canTriggerEvent("hero_health_low") {
 			hc.handleEvent("hero_health_low", map[string]interface{}{
 				"health":      health,
@@ -125,13 +180,6 @@ func (hc *HeroConsumer) processHeroChanges(event events.TickEvent) {
 				"level_diff": level - hc.lastLevel,
 			})
 		}
-
-		// Check for ultimate ready at level 6
-		if level == 6 && hc.lastLevel < 6 && hc.isEventEnabled("hero_ultimate_ready") {
-			hc.handleEvent("hero_ultimate_ready", map[string]interface{}{
-				"level": level,
-			})
-		}
 	}
 
 	// Update last known values (only if we have valid data)
@@ -149,6 +197,47 @@ func (hc *HeroConsumer) processHeroChanges(event events.TickEvent) {
 	}
 }
 
+// processRespawn speaks the last seconds of the respawn timer and announces
+// the hero coming back to life
+func (hc *HeroConsumer) processRespawn(state *events.GameState) {
+	if state.Hero.Name == "" {
+		return // No hero data this tick
+	}
+
+	if !state.Hero.Alive {
+		hc.dead = true
+
+		// Optional countdown, one announcement per second
+		seconds := state.Hero.RespawnSeconds
+		countdown := hc.getTimingValue("hero_respawn_countdown", "warning_seconds", config.DefaultRespawnCountdown)
+		if seconds > 0 && seconds <= countdown && seconds != hc.lastCountdown {
+			hc.lastCountdown = seconds
+			if hc.isEventEnabled("hero_respawn_countdown") {
+				hc.handleEvent("hero_respawn_countdown", map[string]interface{}{
+					"seconds": seconds,
+				})
+			}
+		}
+		return
+	}
+
+	if hc.dead {
+		if hc.isEventEnabled("hero_respawned") {
+			hc.handleEvent("hero_respawned", map[string]interface{}{
+				"buyback_available": buybackAvailable(state),
+			})
+		}
+	}
+	hc.dead = false
+	hc.deathPending = false // Bought back (or respawned) before the timer showed up
+	hc.lastCountdown = 0
+}
+
+// buybackAvailable reports whether buyback is off cooldown and affordable
+func buybackAvailable(state *events.GameState) bool {
+	return state.Hero.BuybackCost > 0 && state.Hero.BuybackCooldown == 0 && state.Player.Gold >= state.Hero.BuybackCost
+}
+
 // canTriggerEvent checks if enough time has passed since last event
 func (hc *HeroConsumer) canTriggerEvent(eventType string) bool {
 	// Get throttle duration for this event type
@@ -169,15 +258,53 @@ func (hc *HeroConsumer) canTriggerEvent(eventType string) bool {
 
 // getThrottleDuration returns the throttle duration for an event type
 func (hc *HeroConsumer) getThrottleDuration(eventType string) time.Duration {
+	fallback := int64(10) // Default fallback (seconds)
 	if duration, exists := hc.throttleConfig[eventType]; exists {
-		return duration
+		fallback = int64(duration / time.Second)
 	}
-	return 10 * time.Second // Default fallback
+	return time.Duration(hc.getTimingValue(eventType, "throttle", fallback)) * time.Second
 }
 
-// isEventEnabled checks if an event is enabled (always true for now)
+// getTimingValue reads a numeric field of a hero timing
+func (hc *HeroConsumer) getTimingValue(eventType, field string, fallback int64) int64 {
+	if hc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := hc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig(eventType); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if a hero event is enabled
 func (hc *HeroConsumer) isEventEnabled(eventType string) bool {
-	return true // All events enabled by default
+	if hc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := hc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
 }
 
 // handleEvent sends event to all handlers
@@ -0,0 +1,252 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// emptySlot is the item name GSI sends for free slots
+const emptySlot = "empty"
+
+// ItemsConsumer parses items.slot*, items.stash* and items.teleport0 and emits
+// tp_ready, item_purchased and item_ready (watch-list items coming off cooldown)
+type ItemsConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu         sync.Mutex       // Guards per-match state (Reset runs on the session goroutine)
+	tracking   bool             // The fields below hold the previous tick
+	counts     map[string]int   // Owned count per item name (slots, stash, teleport)
+	cooldowns  map[string]int64 // Highest cooldown per item name in the inventory
+	tpCooldown int64
+	gold       int64
+}
+
+// NewItemsConsumer creates a new items consumer
+func NewItemsConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *ItemsConsumer {
+	return &ItemsConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "items", Policy: events.DropOldest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+	}
+}
+
+// Start begins consuming events
+func (ic *ItemsConsumer) Start() {
+	go ic.consume()
+	ic.logger.Info("🎒 ItemsConsumer started")
+}
+
+// Stop stops the consumer
+func (ic *ItemsConsumer) Stop() {
+	close(ic.stopChan)
+	ic.eventBus.Unsubscribe(ic.eventChan)
+	ic.logger.Info("🎒 ItemsConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (ic *ItemsConsumer) Reset() {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	ic.tracking = false
+	ic.counts = nil
+	ic.cooldowns = nil
+	ic.tpCooldown = 0
+	ic.gold = 0
+}
+
+// consume processes TickEvents
+func (ic *ItemsConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-ic.eventChan:
+			if !ok {
+				return
+			}
+			ic.processItems(event)
+		case <-ic.stopChan:
+			return
+		}
+	}
+}
+
+// processItems compares the items block with the previous tick
+func (ic *ItemsConsumer) processItems(event events.TickEvent) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if ic.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if len(state.Items) == 0 || state.Map.GameState == "" {
+		ic.tracking = false
+		return
+	}
+
+	counts := make(map[string]int)
+	cooldowns := make(map[string]int64)
+	for slot, item := range state.Items {
+		if item.Name == "" || item.Name == emptySlot {
+			continue
+		}
+		if strings.HasPrefix(slot, "slot") || strings.HasPrefix(slot, "stash") || slot == "teleport0" {
+			counts[item.Name]++
+		}
+		// Only inventory items can be cast (stash cooldowns don't matter)
+		if strings.HasPrefix(slot, "slot") || slot == "neutral0" {
+			if current, exists := cooldowns[item.Name]; !exists || item.Cooldown > current {
+				cooldowns[item.Name] = item.Cooldown
+			}
+		}
+	}
+
+	tp := state.Items["teleport0"]
+	tpCooldown := int64(0)
+	if tp.Name != "" && tp.Name != emptySlot {
+		tpCooldown = tp.Cooldown
+	}
+	gold := state.Player.Gold
+
+	// First tick only sets the baseline
+	if ic.tracking {
+		ic.checkTP(tpCooldown)
+		ic.checkPurchases(counts, gold)
+		ic.checkWatchList(cooldowns)
+	}
+
+	ic.tracking = true
+	ic.counts = counts
+	ic.cooldowns = cooldowns
+	ic.tpCooldown = tpCooldown
+	ic.gold = gold
+}
+
+// checkTP announces the TP scroll coming off cooldown
+func (ic *ItemsConsumer) checkTP(tpCooldown int64) {
+	if ic.tpCooldown > 0 && tpCooldown == 0 && ic.isEventEnabled(handlers.EventTPReady) {
+		ic.handleEvent(handlers.EventTPReady, map[string]interface{}{
+			"item": "TP",
+		})
+	}
+}
+
+// checkPurchases announces new items. Gold has to drop in the same tick, so
+// items coming back from the courier or picked up from the ground are ignored.
+func (ic *ItemsConsumer) checkPurchases(counts map[string]int, gold int64) {
+	if gold >= ic.gold || !ic.isEventEnabled(handlers.EventItemPurchased) {
+		return
+	}
+
+	for name, count := range counts {
+		if count > ic.counts[name] {
+			ic.handleEvent(handlers.EventItemPurchased, map[string]interface{}{
+				"item":      itemDisplayName(name),
+				"item_name": name,
+				"cost":      ic.gold - gold,
+			})
+		}
+	}
+}
+
+// checkWatchList announces watch-list items coming off cooldown
+func (ic *ItemsConsumer) checkWatchList(cooldowns map[string]int64) {
+	if !ic.isEventEnabled(handlers.EventItemReady) {
+		return
+	}
+
+	for _, name := range ic.getWatchList() {
+		// Still in the inventory (selling it also clears the cooldown)
+		cooldown, owned := cooldowns[name]
+		if owned && cooldown == 0 && ic.cooldowns[name] > 0 {
+			ic.handleEvent(handlers.EventItemReady, map[string]interface{}{
+				"item":      itemDisplayName(name),
+				"item_name": name,
+			})
+		}
+	}
+}
+
+// getWatchList returns the configured watch list with "item_" prefixes
+func (ic *ItemsConsumer) getWatchList() []string {
+	if ic.gameConfig == nil {
+		return nil
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetItemWatchList() []string
+	}
+
+	gc, ok := ic.gameConfig.(GameConfigInterface)
+	if !ok {
+		return nil
+	}
+
+	watchList := gc.GetItemWatchList()
+	names := make([]string, 0, len(watchList))
+	for _, name := range watchList {
+		names = append(names, NormalizeItemName(name))
+	}
+	return names
+}
+
+// NormalizeItemName turns "Black King Bar" or "black_king_bar" into the GSI
+// name ("item_black_king_bar")
+func NormalizeItemName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, " ", "_")
+	if !strings.HasPrefix(name, "item_") {
+		name = "item_" + name
+	}
+	return name
+}
+
+// itemDisplayName turns a GSI item name into something speakable
+// ("item_black_king_bar" -> "black king bar")
+func itemDisplayName(name string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(name, "item_"), "_", " ")
+}
+
+// isEventEnabled checks if an item alert is enabled
+func (ic *ItemsConsumer) isEventEnabled(eventType string) bool {
+	if ic.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := ic.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (ic *ItemsConsumer) handleEvent(eventType string, data interface{}) {
+	ic.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("🎒 Item event triggered")
+
+	for _, handler := range ic.handlers {
+		handler.Handle(eventType, data)
+	}
+}
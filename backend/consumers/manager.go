@@ -3,6 +3,7 @@ package consumers
 import (
 	"dota-gsi/backend/events"
 	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/plugins"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,10 +14,18 @@ type Consumer interface {
 	Stop()
 }
 
+// Resettable is implemented by consumers that keep per-match state
+// (dedupe maps, last seen values) which must be cleared between matches
+type Resettable interface {
+	Reset()
+}
+
 // ConsumerManager manages multiple domain consumers
 type ConsumerManager struct {
 	consumers []Consumer
 	logger    *logrus.Entry
+	roshan    *RoshanConsumer    // Kept for the manual "Roshan died" API
+	tormentor *TormentorConsumer // Kept for the manual "Tormentor died" API
 }
 
 // NewConsumerManager creates a new consumer manager
@@ -28,43 +37,116 @@ func NewConsumerManager(logger *logrus.Entry) *ConsumerManager {
 }
 
 // AddMapConsumer adds a MapConsumer to the manager
-func (cm *ConsumerManager) AddMapConsumer(eventBus *events.EventBus, handlerList []handlers.Handler) {
-	mapConsumer := NewMapConsumer(eventBus, cm.logger.WithField("consumer", "map"), handlerList)
+func (cm *ConsumerManager) AddMapConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	mapConsumer := NewMapConsumer(eventBus, cm.logger.WithField("consumer", "map"), handlerList, gameConfig)
 	cm.consumers = append(cm.consumers, mapConsumer)
 }
 
 // AddHeroConsumer adds a HeroConsumer to the manager
-func (cm *ConsumerManager) AddHeroConsumer(eventBus *events.EventBus, handlerList []handlers.Handler) {
-	heroConsumer := NewHeroConsumer(eventBus, cm.logger.WithField("consumer", "hero"), handlerList)
+func (cm *ConsumerManager) AddHeroConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	heroConsumer := NewHeroConsumer(eventBus, cm.logger.WithField("consumer", "hero"), handlerList, gameConfig)
 	cm.consumers = append(cm.consumers, heroConsumer)
 }
 
-// AddRuneConsumer adds a RuneConsumer to the manager
-func (cm *ConsumerManager) AddRuneConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
-	runeConsumer := NewRuneConsumer(eventBus, cm.logger.WithField("consumer", "rune"), handlerList, gameConfig)
-	cm.consumers = append(cm.consumers, runeConsumer)
+// AddStatusConsumer adds a hero StatusConsumer to the manager
+func (cm *ConsumerManager) AddStatusConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	statusConsumer := NewStatusConsumer(eventBus, cm.logger.WithField("consumer", "status"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, statusConsumer)
+}
+
+// AddTimerConsumer adds the TimerConsumer (runes, catapults, stacks, lotus, outposts, day/night, custom timers) to the manager
+func (cm *ConsumerManager) AddTimerConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	timerConsumer := NewTimerConsumer(eventBus, cm.logger.WithField("consumer", "timer"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, timerConsumer)
+}
+
+// AddRoshanConsumer adds a RoshanConsumer to the manager
+func (cm *ConsumerManager) AddRoshanConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	cm.roshan = NewRoshanConsumer(eventBus, cm.logger.WithField("consumer", "roshan"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, cm.roshan)
+}
+
+// AddBuybackConsumer adds a BuybackConsumer to the manager
+func (cm *ConsumerManager) AddBuybackConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	consumer := NewBuybackConsumer(eventBus, cm.logger.WithField("consumer", "buyback"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, consumer)
+}
+
+// AddGlyphConsumer adds a GlyphConsumer to the manager
+func (cm *ConsumerManager) AddGlyphConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	consumer := NewGlyphConsumer(eventBus, cm.logger.WithField("consumer", "glyph"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, consumer)
+}
+
+// AddTormentorConsumer adds a TormentorConsumer to the manager
+func (cm *ConsumerManager) AddTormentorConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	cm.tormentor = NewTormentorConsumer(eventBus, cm.logger.WithField("consumer", "tormentor"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, cm.tormentor)
+}
+
+// AddDraftConsumer adds a DraftConsumer to the manager
+func (cm *ConsumerManager) AddDraftConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	draftConsumer := NewDraftConsumer(eventBus, cm.logger.WithField("consumer", "draft"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, draftConsumer)
+}
+
+// AddSessionManager adds a MatchSessionManager that resets every consumer between matches
+func (cm *ConsumerManager) AddSessionManager(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	sessionManager := NewMatchSessionManager(eventBus, cm.logger.WithField("consumer", "session"), handlerList, cm.ResetAll, gameConfig)
+	cm.consumers = append(cm.consumers, sessionManager)
 }
 
-// AddTimingConsumer adds a TimingConsumer to the manager
-func (cm *ConsumerManager) AddTimingConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
-	timingConsumer := NewTimingConsumer(eventBus, cm.logger.WithField("consumer", "timing"), handlerList, gameConfig)
-	cm.consumers = append(cm.consumers, timingConsumer)
+// AddGameConsumers adds every consumer that drives in-game alerts.
+// Shared by the server and the headless replay command so both run the same pipeline.
+func (cm *ConsumerManager) AddGameConsumers(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	cm.AddSessionManager(eventBus, handlerList, gameConfig)
+	cm.AddDraftConsumer(eventBus, handlerList, gameConfig)
+	cm.AddMapConsumer(eventBus, handlerList, gameConfig)
+	cm.AddHeroConsumer(eventBus, handlerList, gameConfig)
+	cm.AddStatusConsumer(eventBus, handlerList, gameConfig)
+	cm.AddTimerConsumer(eventBus, handlerList, gameConfig)
+	cm.AddRoshanConsumer(eventBus, handlerList, gameConfig)
+	cm.AddTormentorConsumer(eventBus, handlerList, gameConfig)
+	cm.AddBuybackConsumer(eventBus, handlerList, gameConfig)
+	cm.AddGlyphConsumer(eventBus, handlerList, gameConfig)
+	cm.AddItemsConsumer(eventBus, handlerList, gameConfig)
+	cm.AddAbilitiesConsumer(eventBus, handlerList, gameConfig)
+	cm.AddRuleConsumer(eventBus, handlerList, gameConfig)
+	cm.AddScriptConsumers(eventBus, handlerList)
 }
 
-// AddAbilitiesConsumer adds an AbilitiesConsumer to the manager (future implementation)
-func (cm *ConsumerManager) AddAbilitiesConsumer(eventBus *events.EventBus, handlerList []handlers.Handler) {
-	// TODO: Implement AbilitiesConsumer
-	// abilitiesConsumer := NewAbilitiesConsumer(eventBus, cm.logger.WithField("consumer", "abilities"), handlerList)
-	// cm.consumers = append(cm.consumers, abilitiesConsumer)
-	cm.logger.Info("⚡ AbilitiesConsumer will be implemented soon")
+// AddAbilitiesConsumer adds an AbilitiesConsumer to the manager
+func (cm *ConsumerManager) AddAbilitiesConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	abilitiesConsumer := NewAbilitiesConsumer(eventBus, cm.logger.WithField("consumer", "abilities"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, abilitiesConsumer)
 }
 
-// AddItemsConsumer adds an ItemsConsumer to the manager (future implementation)
-func (cm *ConsumerManager) AddItemsConsumer(eventBus *events.EventBus, handlerList []handlers.Handler) {
-	// TODO: Implement ItemsConsumer
-	// itemsConsumer := NewItemsConsumer(eventBus, cm.logger.WithField("consumer", "items"), handlerList)
-	// cm.consumers = append(cm.consumers, itemsConsumer)
-	cm.logger.Info("🎒 ItemsConsumer will be implemented soon")
+// AddRuleConsumer adds the user-defined alert rule consumer to the manager
+func (cm *ConsumerManager) AddRuleConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	ruleConsumer := NewRuleConsumer(eventBus, cm.logger.WithField("consumer", "rule"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, ruleConsumer)
+}
+
+// AddScriptConsumers loads the script plugins from the app data directory
+// and adds one consumer per script
+func (cm *ConsumerManager) AddScriptConsumers(eventBus *events.EventBus, handlerList []handlers.Handler) {
+	dir, err := plugins.Dir()
+	if err != nil {
+		cm.logger.WithError(err).Warn("⚠️ Scripts directory unavailable")
+		return
+	}
+
+	for _, script := range plugins.LoadDir(dir, cm.logger.WithField("consumer", "script")) {
+		logger := cm.logger.WithFields(logrus.Fields{"consumer": "script", "script": script.Name})
+		cm.consumers = append(cm.consumers, NewScriptConsumer(script, eventBus, logger, handlerList))
+		cm.logger.WithField("script", script.Name).Info("📜 Script plugin loaded")
+	}
+}
+
+// AddItemsConsumer adds an ItemsConsumer to the manager
+func (cm *ConsumerManager) AddItemsConsumer(eventBus *events.EventBus, handlerList []handlers.Handler, gameConfig interface{}) {
+	itemsConsumer := NewItemsConsumer(eventBus, cm.logger.WithField("consumer", "items"), handlerList, gameConfig)
+	cm.consumers = append(cm.consumers, itemsConsumer)
 }
 
 // StartAll starts all registered consumers
@@ -89,6 +171,29 @@ func (cm *ConsumerManager) StopAll() {
 	cm.logger.Info("✅ All consumers stopped")
 }
 
+// ResetAll clears the per-match state of every Resettable consumer
+func (cm *ConsumerManager) ResetAll() {
+	count := 0
+	for _, consumer := range cm.consumers {
+		if resettable, ok := consumer.(Resettable); ok {
+			resettable.Reset()
+			count++
+		}
+	}
+
+	cm.logger.WithField("count", count).Info("🔄 Consumer state reset for new match")
+}
+
+// Roshan returns the Roshan consumer (nil if not added)
+func (cm *ConsumerManager) Roshan() *RoshanConsumer {
+	return cm.roshan
+}
+
+// Tormentor returns the Tormentor consumer (nil if not added)
+func (cm *ConsumerManager) Tormentor() *TormentorConsumer {
+	return cm.tormentor
+}
+
 // Count returns the number of registered consumers
 func (cm *ConsumerManager) Count() int {
 	return len(cm.consumers)
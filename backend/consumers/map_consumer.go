@@ -3,29 +3,42 @@ package consumers
 import (
 	"dota-gsi/backend/events"
 	"dota-gsi/backend/handlers"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// mapPaths are the fields whose changes the MapConsumer announces
+var mapPaths = []string{"map.game_state", "map.daytime", "map.radiant_score", "map.dire_score"}
+
 // MapConsumer processes map-related events (game state, day/night, score)
+// from change subscriptions, so it needs no tick subscription and keeps no
+// shadow state of its own besides the per-event throttle
 type MapConsumer struct {
-	logger        *logrus.Entry
-	lastGameState string
-	lastDaytime   bool
-	lastRadScore  int64
-	lastDireScore int64
-	eventChan     <-chan events.TickEvent
-	stopChan      chan struct{}
-	handlers      []handlers.Handler
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	changeChan <-chan events.ChangeEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu            sync.Mutex           // Guards eventThrottle (Reset runs on the session goroutine)
+	eventThrottle map[string]time.Time // Last time each event was sent
+
+	lastScoreTick *events.GameState // Tick of the last score event (both scores can change on one tick)
 }
 
 // NewMapConsumer creates a new map consumer with handlers
-func NewMapConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler) *MapConsumer {
+func NewMapConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *MapConsumer {
 	return &MapConsumer{
-		logger:    logger,
-		eventChan: eventBus.Subscribe(),
-		stopChan:  make(chan struct{}),
-		handlers:  handlerList,
+		logger:        logger,
+		eventBus:      eventBus,
+		changeChan:    eventBus.SubscribeChanges(mapPaths...),
+		stopChan:      make(chan struct{}),
+		handlers:      handlerList,
+		gameConfig:    gameConfig,
+		eventThrottle: make(map[string]time.Time),
 	}
 }
 
@@ -38,73 +51,148 @@ func (mc *MapConsumer) Start() {
 // Stop stops the consumer
 func (mc *MapConsumer) Stop() {
 	close(mc.stopChan)
+	mc.eventBus.UnsubscribeChanges(mc.changeChan)
 	mc.logger.Info("🗺️ MapConsumer stopped")
 }
 
-// consume processes TickEvents and detects map changes
+// Reset clears the throttle (called by the session manager between matches)
+func (mc *MapConsumer) Reset() {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.eventThrottle = make(map[string]time.Time)
+}
+
+// consume processes ChangeEvents of the map fields
 func (mc *MapConsumer) consume() {
 	for {
 		select {
-		case event := <-mc.eventChan:
-			mc.processMapChanges(event)
+		case change, ok := <-mc.changeChan:
+			if !ok {
+				return
+			}
+			mc.processMapChange(change)
 		case <-mc.stopChan:
 			return
 		}
 	}
 }
 
-// processMapChanges extracts map data and detects changes
-func (mc *MapConsumer) processMapChanges(event events.TickEvent) {
-	// Use parsed event for efficient JSON access
-	parsed := events.NewParsedTickEvent(event)
-	
-	// Extract map data using cached parse
-	gameState := parsed.GetString("map.game_state")
-	daytime := parsed.GetBool("map.daytime")
-	radiantScore := parsed.GetInt64("map.radiant_score")
-	direScore := parsed.GetInt64("map.dire_score")
-
-	// Check for game state changes
-	if gameState != "" && gameState != mc.lastGameState && mc.lastGameState != "" {
-		if mc.isEventEnabled("game_state_change") {
+// processMapChange announces a change of one of the map fields
+func (mc *MapConsumer) processMapChange(change events.ChangeEvent) {
+	if mc.eventBus.Stale(change.Generation) {
+		return // Change of the previous match, queued before the reset
+	}
+
+	state := change.State
+
+	switch change.Path {
+	case "map.game_state":
+		if change.Previous.String() != "" && state.Map.GameState != "" &&
+			!mc.hasDedicatedEvent(change.Previous.String(), state.Map.GameState) && mc.isEventEnabled("game_state_change") {
 			mc.handleEvent("game_state_change", map[string]interface{}{
-				"from": mc.lastGameState,
-				"to":   gameState,
+				"from": change.Previous.String(),
+				"to":   state.Map.GameState,
 			})
 		}
-	}
 
-	// Check for day/night changes
-	if daytime != mc.lastDaytime && mc.lastGameState != "" {
-		if mc.isEventEnabled("day_night_change") {
+	case "map.daytime":
+		if change.Previous.Exists() && mc.isEventEnabled("day_night_change") {
 			mc.handleEvent("day_night_change", map[string]interface{}{
-				"daytime": daytime,
+				"daytime": state.Map.Daytime,
 			})
 		}
-	}
 
-	// Check for score changes
-	if (radiantScore != mc.lastRadScore || direScore != mc.lastDireScore) && mc.lastGameState != "" {
+	case "map.radiant_score", "map.dire_score":
+		// One score event per tick, with both diffs
+		if state == mc.lastScoreTick {
+			return
+		}
+		mc.lastScoreTick = state
+
+		radiantChange, _ := state.Change("map.radiant_score")
+		direChange, _ := state.Change("map.dire_score")
+		if !radiantChange.Previous.Exists() && !direChange.Previous.Exists() {
+			return
+		}
 		if mc.isEventEnabled("score_change") {
 			mc.handleEvent("score_change", map[string]interface{}{
-				"radiant_score": radiantScore,
-				"dire_score":    direScore,
-				"radiant_diff":  radiantScore - mc.lastRadScore,
-				"dire_diff":     direScore - mc.lastDireScore,
+				"radiant_score": state.Map.RadiantScore,
+				"dire_score":    state.Map.DireScore,
+				"radiant_diff":  scoreDiff(radiantChange, state.Map.RadiantScore),
+				"dire_diff":     scoreDiff(direChange, state.Map.DireScore),
 			})
 		}
 	}
+}
 
-	// Update last known values
-	mc.lastGameState = gameState
-	mc.lastDaytime = daytime
-	mc.lastRadScore = radiantScore
-	mc.lastDireScore = direScore
+// scoreDiff returns how much a score went up this tick (0 if it didn't change)
+func scoreDiff(change events.Change, current int64) int64 {
+	if !change.Previous.Exists() {
+		return 0
+	}
+	return current - change.Previous.Int()
 }
 
-// isEventEnabled checks if an event is enabled (always true for now)
+// hasDedicatedEvent checks if a game state transition is already announced by
+// its own event (strategy time by the DraftConsumer), so it isn't spoken twice
+func (mc *MapConsumer) hasDedicatedEvent(from, to string) bool {
+	if from != events.GameStateHeroSelection || to != events.GameStateStrategyTime {
+		return false
+	}
+	if mc.gameConfig == nil {
+		return true // The draft consumer defaults to enabled too
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := mc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(EventStrategyTime)
+	}
+
+	return true
+}
+
+// isEventEnabled checks if a map event is enabled and not throttled
 func (mc *MapConsumer) isEventEnabled(eventType string) bool {
-	return true // All events enabled by default
+	if mc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	gc, ok := mc.gameConfig.(GameConfigInterface)
+	if !ok {
+		return true // Default to enabled
+	}
+	if !gc.IsTimingEnabled(eventType) {
+		return false
+	}
+
+	// Optional "throttle" field (seconds between two events of this type)
+	throttle := int64(0)
+	if cfg := gc.GetTimingConfig(eventType); cfg != nil {
+		if converted, ok := toInt64Safe(cfg["throttle"]); ok {
+			throttle = converted
+		}
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if throttle > 0 {
+		if last, exists := mc.eventThrottle[eventType]; exists && time.Since(last) < time.Duration(throttle)*time.Second {
+			return false
+		}
+	}
+	mc.eventThrottle[eventType] = time.Now()
+	return true
 }
 
 // handleEvent sends event to all handlers
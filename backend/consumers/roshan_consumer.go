@@ -0,0 +1,406 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/i18n"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Roshan timing constants (Dota 2 game rules)
+const (
+	RoshanRespawnMin int64 = 480 // Roshan respawns 8 to 11 minutes after dying
+	RoshanRespawnMax int64 = 660
+	AegisDuration    int64 = 300 // Aegis expires 5 minutes after pickup
+	staleEventAge    int64 = 10  // Events older than this (e.g. on reconnect) are not announced
+	aegisItem              = "item_aegis"
+)
+
+// Roshan events
+const (
+	EventRoshanKilled    = "roshan_killed"
+	EventRoshanMinWindow = "roshan_min_window"
+	EventRoshanMaxWindow = "roshan_max_window"
+	EventAegisExpiring   = "aegis_expiring"
+)
+
+// RoshanConsumer tracks Roshan respawn windows and the Aegis. Kills come from
+// the GSI events block (roshan_killed, aegis_picked_up) or from MarkKilled
+// when the client doesn't send events.
+type RoshanConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu           sync.Mutex      // Guards per-match state (Reset runs on the session goroutine)
+	seenEvents   map[string]bool // GSI events already handled (the block repeats them)
+	kills        int64           // Roshan kills this match
+	killedAt     int64           // Clock of the last kill (-1 while Roshan is alive)
+	aegisAt      int64           // Clock the Aegis was picked up (-1 if nobody holds it)
+	aegisHeld    bool            // The Aegis has shown up in our items since the pickup
+	alerted      map[string]bool // Alerts already sent for the current kill/Aegis
+	lastClock    int64
+	clockRunning bool // Clock of a running match has been seen
+}
+
+// NewRoshanConsumer creates a new Roshan consumer
+func NewRoshanConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *RoshanConsumer {
+	rc := &RoshanConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "roshan", Policy: events.DropOldest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+	}
+	rc.resetState()
+	return rc
+}
+
+// Start begins consuming events
+func (rc *RoshanConsumer) Start() {
+	go rc.consume()
+	rc.logger.Info("🐉 RoshanConsumer started")
+}
+
+// Stop stops the consumer
+func (rc *RoshanConsumer) Stop() {
+	close(rc.stopChan)
+	rc.eventBus.Unsubscribe(rc.eventChan)
+	rc.logger.Info("🐉 RoshanConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (rc *RoshanConsumer) Reset() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.resetState()
+}
+
+// resetState clears per-match state (caller holds mu)
+func (rc *RoshanConsumer) resetState() {
+	rc.seenEvents = make(map[string]bool)
+	rc.kills = 0
+	rc.killedAt = -1
+	rc.aegisAt = -1
+	rc.aegisHeld = false
+	rc.alerted = make(map[string]bool)
+	rc.lastClock = 0
+	rc.clockRunning = false
+}
+
+// MarkKilled records a Roshan kill at the current game clock (manual trigger
+// for clients without the events block). The Aegis is assumed picked up.
+func (rc *RoshanConsumer) MarkKilled() (map[string]interface{}, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if !rc.clockRunning {
+		return nil, fmt.Errorf("no match in progress")
+	}
+
+	rc.recordKill(rc.lastClock, true)
+	rc.recordAegis(rc.lastClock)
+	return rc.statusLocked(), nil
+}
+
+// Status returns the current Roshan and Aegis timers
+func (rc *RoshanConsumer) Status() map[string]interface{} {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.statusLocked()
+}
+
+// consume processes TickEvents
+func (rc *RoshanConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-rc.eventChan:
+			if !ok {
+				return
+			}
+			rc.processRoshan(event)
+		case <-rc.stopChan:
+			return
+		}
+	}
+}
+
+// processRoshan handles new GSI events and checks the respawn/Aegis timers
+func (rc *RoshanConsumer) processRoshan(event events.TickEvent) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if !state.Map.InProgress() {
+		return
+	}
+
+	rc.lastClock = state.Map.ClockTime
+	rc.clockRunning = true
+
+	// Kills and pickups are recorded even while paused
+	rc.processGameEvents(state)
+	rc.checkAegisHolder(state)
+
+	// Hold alerts while paused (they fire on resume if still due)
+	if state.Map.Paused {
+		return
+	}
+
+	if !rc.isEventEnabled() {
+		return
+	}
+
+	rc.checkRespawnWindows(state.Map.ClockTime)
+	rc.checkAegis(state.Map.ClockTime)
+}
+
+// processGameEvents records roshan_killed / aegis_picked_up / aegis_denied
+func (rc *RoshanConsumer) processGameEvents(state *events.GameState) {
+	for _, gameEvent := range state.Events {
+		key := fmt.Sprintf("%s@%d", gameEvent.EventType, gameEvent.GameTime)
+		if rc.seenEvents[key] {
+			continue
+		}
+
+		clock := state.Map.ClockAt(gameEvent.GameTime)
+		switch gameEvent.EventType {
+		case events.GameEventRoshanKilled:
+			rc.recordKill(clock, state.Map.ClockTime-clock <= staleEventAge)
+		case events.GameEventAegisPickedUp:
+			rc.recordAegis(clock)
+		case events.GameEventAegisDenied:
+			rc.clearAegis()
+		default:
+			continue
+		}
+		rc.seenEvents[key] = true
+	}
+}
+
+// recordKill starts the respawn timers and announces the next drops (caller holds mu)
+func (rc *RoshanConsumer) recordKill(clock int64, announce bool) {
+	rc.kills++
+	rc.killedAt = clock
+	rc.alerted = make(map[string]bool)
+
+	rc.logger.WithFields(logrus.Fields{
+		"kills":      rc.kills,
+		"clock_time": clock,
+	}).Info("🐉 Roshan killed")
+
+	if !announce || !rc.isEventEnabled() {
+		return
+	}
+
+	rc.handleEvent(EventRoshanKilled, map[string]interface{}{
+		"kills":      rc.kills,
+		"min_time":   formatClock(clock + RoshanRespawnMin),
+		"max_time":   formatClock(clock + RoshanRespawnMax),
+		"drops":      roshanDrops(rc.kills + 1),
+		"clock_time": clock,
+	})
+}
+
+// recordAegis starts the Aegis countdown (caller holds mu)
+func (rc *RoshanConsumer) recordAegis(clock int64) {
+	rc.aegisAt = clock
+	rc.aegisHeld = false
+	delete(rc.alerted, EventAegisExpiring)
+}
+
+// clearAegis stops the Aegis countdown and drops its pending warning (caller holds mu)
+func (rc *RoshanConsumer) clearAegis() {
+	rc.aegisAt = -1
+	rc.aegisHeld = false
+	delete(rc.alerted, EventAegisExpiring)
+}
+
+// checkAegisHolder stops the countdown once the Aegis we were holding is gone
+// (consumed on death or dropped). Only our own items are visible, so an Aegis
+// held by another player runs until it expires.
+func (rc *RoshanConsumer) checkAegisHolder(state *events.GameState) {
+	if rc.aegisAt < 0 || len(state.Items) == 0 {
+		return
+	}
+
+	for _, item := range state.Items {
+		if item.Name == aegisItem {
+			rc.aegisHeld = true
+			if state.Hero.Alive {
+				return
+			}
+			break
+		}
+	}
+
+	if rc.aegisHeld {
+		rc.logger.WithField("clock_time", state.Map.ClockTime).Info("🐉 Aegis consumed")
+		rc.clearAegis()
+	}
+}
+
+// checkRespawnWindows warns before the minimum and maximum respawn times
+func (rc *RoshanConsumer) checkRespawnWindows(clock int64) {
+	if rc.killedAt < 0 {
+		return
+	}
+
+	minSpawn := rc.killedAt + RoshanRespawnMin
+	maxSpawn := rc.killedAt + RoshanRespawnMax
+
+	// Roshan is certainly back - stop tracking this kill
+	if clock >= maxSpawn {
+		rc.killedAt = -1
+		return
+	}
+
+	rc.checkWindow(EventRoshanMinWindow, minSpawn, clock, rc.getTimingValue("minimum", config.DefaultRoshanWarning))
+	rc.checkWindow(EventRoshanMaxWindow, maxSpawn, clock, rc.getTimingValue("maximum", config.DefaultRoshanWarning))
+}
+
+// checkWindow sends a window alert once when it's within warningSeconds
+func (rc *RoshanConsumer) checkWindow(eventType string, spawnTime, clock, warningSeconds int64) {
+	timeUntil := spawnTime - clock
+	if timeUntil < 0 || timeUntil > warningSeconds || rc.alerted[eventType] {
+		return
+	}
+
+	rc.handleEvent(eventType, map[string]interface{}{
+		"seconds":    timeUntil,
+		"spawn_time": spawnTime,
+		"drops":      roshanDrops(rc.kills + 1),
+	})
+	rc.alerted[eventType] = true
+}
+
+// checkAegis warns before the Aegis expires
+func (rc *RoshanConsumer) checkAegis(clock int64) {
+	if rc.aegisAt < 0 {
+		return
+	}
+
+	expiresAt := rc.aegisAt + AegisDuration
+	if clock >= expiresAt {
+		rc.aegisAt = -1
+		return
+	}
+
+	timeUntil := expiresAt - clock
+	if timeUntil > rc.getTimingValue("aegis", config.DefaultAegisWarning) || rc.alerted[EventAegisExpiring] {
+		return
+	}
+
+	rc.handleEvent(EventAegisExpiring, map[string]interface{}{
+		"seconds":    timeUntil,
+		"expires_at": expiresAt,
+	})
+	rc.alerted[EventAegisExpiring] = true
+}
+
+// statusLocked returns the current timers (caller holds mu)
+func (rc *RoshanConsumer) statusLocked() map[string]interface{} {
+	status := map[string]interface{}{
+		"kills":      rc.kills,
+		"alive":      rc.killedAt < 0,
+		"next_drops": roshanDrops(rc.kills + 1),
+		"clock_time": rc.lastClock,
+	}
+	if rc.killedAt >= 0 {
+		status["killed_at"] = rc.killedAt
+		status["min_spawn"] = rc.killedAt + RoshanRespawnMin
+		status["max_spawn"] = rc.killedAt + RoshanRespawnMax
+	}
+	if rc.aegisAt >= 0 {
+		status["aegis_expires_at"] = rc.aegisAt + AegisDuration
+	}
+	return status
+}
+
+// roshanDrops lists what Roshan drops on the given kill number
+func roshanDrops(kill int64) string {
+	drops := []string{i18n.T("roshan.drops.aegis", nil)}
+	if kill >= 2 {
+		drops = append(drops, i18n.T("roshan.drops.cheese", nil))
+	}
+	if kill >= 3 {
+		drops = append(drops, i18n.T("roshan.drops.refresher_shard", nil))
+	}
+
+	if len(drops) == 1 {
+		return drops[0]
+	}
+	return strings.Join(drops[:len(drops)-1], ", ") + " " + i18n.T("roshan.drops.and", nil) + " " + drops[len(drops)-1]
+}
+
+// formatClock formats a game clock as m:ss
+func formatClock(clock int64) string {
+	return fmt.Sprintf("%d:%02d", clock/MinuteInSeconds, clock%MinuteInSeconds)
+}
+
+// getTimingValue reads a numeric field of the roshan timing
+func (rc *RoshanConsumer) getTimingValue(field string, fallback int64) int64 {
+	if rc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := rc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig("roshan"); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if the roshan timing is enabled
+func (rc *RoshanConsumer) isEventEnabled() bool {
+	if rc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := rc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled("roshan")
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (rc *RoshanConsumer) handleEvent(eventType string, data interface{}) {
+	rc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("🐉 Roshan event triggered")
+
+	for _, handler := range rc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,256 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/rules"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ruleState tracks one alert rule across ticks
+type ruleState struct {
+	since     int64 // Clock when the condition became true (-1 = false)
+	fired     bool  // Already announced for this true run (edge trigger)
+	lastAlert int64 // Clock of the last announcement (-1 = never)
+}
+
+// RuleConsumer evaluates the user's alert rules on every tick. A rule fires
+// once each time its condition becomes true (after holding for its "for"
+// duration) and at most once per throttle.
+type RuleConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu       sync.Mutex             // Guards per-match state (Reset runs on the session goroutine)
+	compiled map[string]*rules.Expr // Compiled conditions by rule key
+	states   map[string]*ruleState  // Per-rule trigger state
+}
+
+// NewRuleConsumer creates a new alert rule consumer
+func NewRuleConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *RuleConsumer {
+	return &RuleConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "rule", Policy: events.KeepLatest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+		compiled:   make(map[string]*rules.Expr),
+		states:     make(map[string]*ruleState),
+	}
+}
+
+// Start begins consuming events
+func (rc *RuleConsumer) Start() {
+	go rc.consume()
+	rc.logger.Info("📐 RuleConsumer started")
+}
+
+// Stop stops the consumer
+func (rc *RuleConsumer) Stop() {
+	close(rc.stopChan)
+	rc.eventBus.Unsubscribe(rc.eventChan)
+	rc.logger.Info("📐 RuleConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (rc *RuleConsumer) Reset() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.states = make(map[string]*ruleState)
+}
+
+// consume processes TickEvents
+func (rc *RuleConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-rc.eventChan:
+			if !ok {
+				return
+			}
+			rc.processRules(event)
+		case <-rc.stopChan:
+			return
+		}
+	}
+}
+
+// processRules evaluates every enabled rule against the tick
+func (rc *RuleConsumer) processRules(event events.TickEvent) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if state.Map.GameState == "" {
+		return // Not in a match
+	}
+	clockTime := state.Map.ClockTime
+
+	for _, rule := range rc.getRules() {
+		if !rc.isEventEnabled(rule.Key) {
+			continue
+		}
+
+		expr := rc.compile(rule)
+		if expr == nil {
+			continue
+		}
+
+		matched, err := rc.match(expr, state)
+		if err != nil {
+			rc.logger.WithError(err).WithField("rule", rule.Key).Debug("📐 Rule evaluation failed")
+			matched = false
+		}
+
+		rc.update(rule, expr, matched, clockTime, state)
+	}
+}
+
+// match evaluates a condition, turning a panic into an error so one bad
+// rule can't take down the server
+func (rc *RuleConsumer) match(expr *rules.Expr, state *events.GameState) (matched bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			matched, err = false, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return expr.Match(state.Get)
+}
+
+// update advances a rule's trigger state and fires it when due
+func (rc *RuleConsumer) update(rule config.AlertRule, expr *rules.Expr, matched bool, clockTime int64, state *events.GameState) {
+	st, exists := rc.states[rule.Key]
+	if !exists {
+		st = &ruleState{since: -1, lastAlert: -1}
+		rc.states[rule.Key] = st
+	}
+
+	if !matched {
+		st.since = -1
+		st.fired = false
+		return
+	}
+
+	// Clock went back (e.g. hero selection -> pre-game): restart the hold
+	if st.since < 0 || clockTime < st.since {
+		st.since = clockTime
+	}
+	if st.fired || clockTime-st.since < expr.For {
+		return
+	}
+	st.fired = true
+
+	throttle := rc.getTimingValue(rule.Key, "throttle", config.DefaultRuleThrottle)
+	if st.lastAlert >= 0 && clockTime >= st.lastAlert && clockTime-st.lastAlert < throttle {
+		return // This edge is swallowed by the throttle
+	}
+	st.lastAlert = clockTime
+
+	// Expose the fields used by the condition to the message ("{player.gold}")
+	data := map[string]interface{}{
+		"name":    rule.Name,
+		"seconds": clockTime - st.since,
+	}
+	for _, path := range expr.Paths() {
+		data[path] = state.Get(path).Value()
+	}
+	rc.handleEvent(rule.Key, data)
+}
+
+// compile returns the compiled condition of a rule (nil if it doesn't compile)
+func (rc *RuleConsumer) compile(rule config.AlertRule) *rules.Expr {
+	if expr, exists := rc.compiled[rule.Key]; exists && expr.Source == rule.Condition {
+		return expr
+	}
+
+	expr, err := rules.Compile(rule.Condition)
+	if err != nil {
+		rc.logger.WithError(err).WithField("rule", rule.Key).Warn("⚠️ Invalid alert rule condition")
+		delete(rc.compiled, rule.Key)
+		return nil
+	}
+	rc.compiled[rule.Key] = expr
+	delete(rc.states, rule.Key) // New condition, new trigger state
+	return expr
+}
+
+// getRules returns the alert rules from config
+func (rc *RuleConsumer) getRules() []config.AlertRule {
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetAlertRules() []config.AlertRule
+	}
+
+	if gc, ok := rc.gameConfig.(GameConfigInterface); ok {
+		return gc.GetAlertRules()
+	}
+
+	return nil
+}
+
+// getTimingValue reads a numeric field of a rule's timing
+func (rc *RuleConsumer) getTimingValue(key, field string, fallback int64) int64 {
+	if rc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := rc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig(key); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if a rule is enabled
+func (rc *RuleConsumer) isEventEnabled(eventType string) bool {
+	if rc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := rc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (rc *RuleConsumer) handleEvent(eventType string, data interface{}) {
+	rc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("📐 Rule triggered")
+
+	for _, handler := range rc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,23 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/schedule"
+)
+
+// activeSchedule returns the timing schedule for this tick: the patch set in
+// config, or the one matching provider.version when it's "auto"
+func activeSchedule(gameConfig interface{}, state *events.GameState) *schedule.Schedule {
+	patch := schedule.Auto
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetSchedulePatch() string
+	}
+
+	if gc, ok := gameConfig.(GameConfigInterface); ok {
+		patch = gc.GetSchedulePatch()
+	}
+
+	return schedule.Default().Select(patch, state.Provider.Version)
+}
@@ -0,0 +1,136 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/plugins"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// scriptEvent is an event emitted by a script during a call
+type scriptEvent struct {
+	eventType string
+	data      map[string]interface{}
+}
+
+// ScriptConsumer runs one script plugin on every tick. Script errors are
+// logged and never reach the other consumers.
+type ScriptConsumer struct {
+	script    *plugins.Script
+	logger    *logrus.Entry
+	eventBus  *events.EventBus
+	eventChan <-chan events.TickEvent
+	stopChan  chan struct{}
+	handlers  []handlers.Handler
+
+	mu      sync.Mutex    // Guards the script runtime (Reset runs on the session goroutine)
+	pending []scriptEvent // Emitted during the current call, dispatched after it returns
+}
+
+// NewScriptConsumer creates a consumer for a loaded script
+func NewScriptConsumer(script *plugins.Script, eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler) *ScriptConsumer {
+	sc := &ScriptConsumer{
+		script:    script,
+		logger:    logger,
+		eventBus:  eventBus,
+		eventChan: eventBus.SubscribeWith(events.SubscribeOptions{Name: "script:" + script.Name, Policy: events.DropOldest}),
+		stopChan:  make(chan struct{}),
+		handlers:  handlerList,
+	}
+	script.OnEmit(func(eventType string, data map[string]interface{}) {
+		sc.pending = append(sc.pending, scriptEvent{eventType, data})
+	})
+	return sc
+}
+
+// Start begins consuming events
+func (sc *ScriptConsumer) Start() {
+	go sc.consume()
+	sc.logger.Info("📜 ScriptConsumer started")
+}
+
+// Stop stops the consumer
+func (sc *ScriptConsumer) Stop() {
+	close(sc.stopChan)
+	sc.eventBus.Unsubscribe(sc.eventChan)
+	sc.logger.Info("📜 ScriptConsumer stopped")
+}
+
+// Reset calls the script's onReset (called by the session manager between matches)
+func (sc *ScriptConsumer) Reset() {
+	sc.mu.Lock()
+	err := sc.script.Reset()
+	pending := sc.takePending()
+	sc.mu.Unlock()
+
+	if err != nil {
+		sc.logger.WithError(err).Warn("⚠️ Script onReset failed")
+	}
+	sc.dispatch(pending)
+}
+
+// consume processes TickEvents
+func (sc *ScriptConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-sc.eventChan:
+			if !ok {
+				return
+			}
+			sc.processTick(event)
+		case <-sc.stopChan:
+			return
+		}
+	}
+}
+
+// processTick runs onTick and dispatches what it emitted
+func (sc *ScriptConsumer) processTick(event events.TickEvent) {
+	sc.mu.Lock()
+	if sc.eventBus.Stale(event.Generation) {
+		sc.mu.Unlock()
+		return // Tick of the previous match, queued before the reset
+	}
+	wasDisabled := sc.script.Disabled()
+	err := sc.script.Tick(event.RawJSON)
+	justDisabled := sc.script.Disabled() && !wasDisabled
+	pending := sc.takePending()
+	sc.mu.Unlock()
+
+	if err != nil {
+		if justDisabled {
+			sc.logger.WithError(err).Error("❌ Script disabled until the next match")
+		} else {
+			sc.logger.WithError(err).Warn("⚠️ Script onTick failed")
+		}
+	}
+	sc.dispatch(pending)
+}
+
+// takePending returns and clears the events emitted by the last call
+func (sc *ScriptConsumer) takePending() []scriptEvent {
+	pending := sc.pending
+	sc.pending = nil
+	return pending
+}
+
+// dispatch sends emitted events to all handlers
+func (sc *ScriptConsumer) dispatch(pending []scriptEvent) {
+	for _, event := range pending {
+		sc.handleEvent(event.eventType, event.data)
+	}
+}
+
+// handleEvent sends event to all handlers
+func (sc *ScriptConsumer) handleEvent(eventType string, data interface{}) {
+	sc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("📜 Script event emitted")
+
+	for _, handler := range sc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,240 @@
+package consumers
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Match lifecycle events
+const (
+	EventMatchStarted = "match_started"
+	EventMatchEnded   = "match_ended"
+	EventGamePaused   = "game_paused"
+	EventGameUnpaused = "game_unpaused"
+)
+
+// PauseTimingKey is the timing entry that turns pause announcements on (off by default)
+const PauseTimingKey = "game_pause"
+
+// sessionEvent is a lifecycle event waiting to be sent to the handlers
+type sessionEvent struct {
+	eventType string
+	data      map[string]interface{}
+}
+
+// MatchSessionManager tracks match boundaries (map.matchid and game state
+// transitions), emits match_started/match_ended and resets per-match consumer
+// state so a second game in the same session starts clean. It also announces
+// pauses when enabled.
+//
+// Ticks are inspected in an EventBus publish hook, so consumers are reset
+// before the first tick of a new match reaches any of them. The hook also
+// starts a new tick generation, and consumers drop ticks of the previous match
+// still sitting in their buffers. Events are handed to the handlers from the
+// manager's own goroutine.
+type MatchSessionManager struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	removeHook func() // Unregisters the publish hook
+	pending    chan sessionEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	onReset    func()      // Resets every consumer (ConsumerManager.ResetAll)
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	processMu sync.Mutex // Serializes hooks (ticks may be published concurrently)
+	mu        sync.Mutex
+	matchID   string
+	gameState string
+	ended     bool
+	clockTime int64 // Last map.clock_time seen in the current match
+}
+
+// NewMatchSessionManager creates a new match session manager
+func NewMatchSessionManager(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, onReset func(), gameConfig interface{}) *MatchSessionManager {
+	return &MatchSessionManager{
+		logger:     logger,
+		eventBus:   eventBus,
+		pending:    make(chan sessionEvent, 100),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		onReset:    onReset,
+		gameConfig: gameConfig,
+	}
+}
+
+// Start begins consuming events
+func (sm *MatchSessionManager) Start() {
+	sm.removeHook = sm.eventBus.OnPublish(sm.processSession)
+	go sm.consume()
+	sm.logger.Info("🎮 MatchSessionManager started")
+}
+
+// Stop stops the consumer
+func (sm *MatchSessionManager) Stop() {
+	if sm.removeHook != nil {
+		sm.removeHook()
+	}
+	close(sm.stopChan)
+	sm.logger.Info("🎮 MatchSessionManager stopped")
+}
+
+// CurrentMatch returns the current match ID ("" when not in a match)
+func (sm *MatchSessionManager) CurrentMatch() string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return sm.matchID
+}
+
+// consume sends queued lifecycle events to the handlers
+func (sm *MatchSessionManager) consume() {
+	for {
+		select {
+		case event := <-sm.pending:
+			sm.dispatch(event)
+		case <-sm.stopChan:
+			return
+		}
+	}
+}
+
+// processSession detects match start/end from the matchid and game state
+// (publish hook: runs before the tick is delivered to the consumers)
+func (sm *MatchSessionManager) processSession(event events.TickEvent) {
+	sm.processMu.Lock()
+	defer sm.processMu.Unlock()
+
+	state := event.State
+	matchID := state.Map.MatchID
+	gameState := state.Map.GameState
+
+	sm.mu.Lock()
+	currentMatch := sm.matchID
+	previousState := sm.gameState
+	ended := sm.ended
+	sm.gameState = gameState
+	if matchID != "" && matchID == currentMatch {
+		sm.clockTime = state.Map.ClockTime
+	}
+	sm.mu.Unlock()
+
+	switch {
+	case matchID != "" && matchID != currentMatch:
+		// New match (a different matchid also closes the one we were in)
+		if currentMatch != "" && !ended {
+			sm.endMatch(currentMatch, "", "new_match")
+		}
+		sm.startMatch(matchID, gameState, state.Map.ClockTime)
+
+	case matchID == "" && currentMatch != "":
+		// Map block is gone - back to the main menu
+		if !ended {
+			sm.endMatch(currentMatch, "", "left_match")
+		}
+		sm.mu.Lock()
+		sm.matchID = ""
+		sm.mu.Unlock()
+
+	case currentMatch != "" && gameState == events.GameStatePostGame && previousState != events.GameStatePostGame && !ended:
+		sm.endMatch(currentMatch, state.Map.WinTeam, "game_over")
+
+	case matchID != "" && !ended:
+		sm.checkPause(state)
+	}
+}
+
+// checkPause announces pause/unpause from the map.paused delta
+func (sm *MatchSessionManager) checkPause(state *events.GameState) {
+	change, ok := state.Change("map.paused")
+	if !ok || !change.Previous.Exists() || !sm.isPauseAnnounceEnabled() {
+		return
+	}
+
+	eventType := EventGameUnpaused
+	if state.Map.Paused {
+		eventType = EventGamePaused
+	}
+
+	sm.handleEvent(eventType, map[string]interface{}{
+		"match_id":   state.Map.MatchID,
+		"clock_time": state.Map.ClockTime,
+	})
+}
+
+// isPauseAnnounceEnabled checks the game_pause timing (off without config)
+func (sm *MatchSessionManager) isPauseAnnounceEnabled() bool {
+	if sm.gameConfig == nil {
+		return false
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := sm.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(PauseTimingKey)
+	}
+
+	return false
+}
+
+// startMatch resets consumer state and emits match_started
+func (sm *MatchSessionManager) startMatch(matchID, gameState string, clockTime int64) {
+	sm.mu.Lock()
+	sm.matchID = matchID
+	sm.ended = false
+	sm.clockTime = clockTime
+	sm.mu.Unlock()
+
+	// Ticks of the old match still queued in the consumers' buffers are
+	// dropped by generation, so they can't refill the state reset here
+	sm.eventBus.NextGeneration()
+	if sm.onReset != nil {
+		sm.onReset()
+	}
+
+	sm.handleEvent(EventMatchStarted, map[string]interface{}{
+		"match_id":   matchID,
+		"game_state": gameState,
+	})
+}
+
+// endMatch emits match_ended (once per match)
+func (sm *MatchSessionManager) endMatch(matchID, winTeam, reason string) {
+	sm.mu.Lock()
+	sm.ended = true
+	clockTime := sm.clockTime
+	sm.mu.Unlock()
+
+	sm.handleEvent(EventMatchEnded, map[string]interface{}{
+		"match_id":   matchID,
+		"win_team":   winTeam,
+		"reason":     reason,
+		"clock_time": clockTime, // Game clock when the match ended
+	})
+}
+
+// handleEvent queues an event for the handlers (never blocks the publisher)
+func (sm *MatchSessionManager) handleEvent(eventType string, data map[string]interface{}) {
+	select {
+	case sm.pending <- sessionEvent{eventType, data}:
+	default:
+		sm.logger.WithField("event_type", eventType).Warn("Match session event dropped - queue full")
+	}
+}
+
+// dispatch sends event to all handlers
+func (sm *MatchSessionManager) dispatch(event sessionEvent) {
+	sm.logger.WithFields(logrus.Fields{
+		"event_type": event.eventType,
+		"data":       event.data,
+	}).Info("🎮 Match session event")
+
+	for _, handler := range sm.handlers {
+		handler.Handle(event.eventType, event.data)
+	}
+}
@@ -0,0 +1,213 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// heroStatus maps one boolean of the hero block to the events it triggers.
+// Each event is also the key of its timing ("enabled", "throttle") and message.
+type heroStatus struct {
+	Name   string                 // Status name used in the event data
+	Get    func(events.Hero) bool // Reads the flag from the hero block
+	OnGain string                 // Event when the status is applied ("" = none)
+	OnLoss string                 // Event when the status ends ("" = none)
+}
+
+// heroStatuses lists the statuses watched by the StatusConsumer
+var heroStatuses = []heroStatus{
+	{Name: "smoked", Get: func(h events.Hero) bool { return h.Smoked }, OnGain: "status_smoked", OnLoss: "smoke_broken"},
+	{Name: "silenced", Get: func(h events.Hero) bool { return h.Silenced }, OnGain: "status_silenced"},
+	{Name: "stunned", Get: func(h events.Hero) bool { return h.Stunned }, OnGain: "status_stunned"},
+	{Name: "hexed", Get: func(h events.Hero) bool { return h.Hexed }, OnGain: "status_hexed"},
+	{Name: "break", Get: func(h events.Hero) bool { return h.Break }, OnGain: "status_broken"},
+	{Name: "magicimmune", Get: func(h events.Hero) bool { return h.MagicImmune }, OnGain: "status_magic_immune", OnLoss: "magic_immune_ended"},
+	{Name: "muted", Get: func(h events.Hero) bool { return h.Muted }, OnGain: "status_muted"},
+	{Name: "disarmed", Get: func(h events.Hero) bool { return h.Disarmed }, OnGain: "status_disarmed"},
+}
+
+// StatusConsumer turns transitions of the hero status flags (smoked,
+// silenced, hexed...) into events. Each event has its own timing with an
+// enable flag and a throttle in game seconds.
+type StatusConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu          sync.Mutex       // Guards per-match state (Reset runs on the session goroutine)
+	tracking    bool             // The flags below hold the previous tick
+	active      map[string]bool  // Status flags on the previous tick
+	lastAlerted map[string]int64 // Clock of the last alert per event type
+}
+
+// NewStatusConsumer creates a new hero status consumer
+func NewStatusConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *StatusConsumer {
+	return &StatusConsumer{
+		logger:      logger,
+		eventBus:    eventBus,
+		eventChan:   eventBus.SubscribeWith(events.SubscribeOptions{Name: "status", Policy: events.DropOldest}),
+		stopChan:    make(chan struct{}),
+		handlers:    handlerList,
+		gameConfig:  gameConfig,
+		active:      make(map[string]bool),
+		lastAlerted: make(map[string]int64),
+	}
+}
+
+// Start begins consuming events
+func (sc *StatusConsumer) Start() {
+	go sc.consume()
+	sc.logger.Info("🌀 StatusConsumer started")
+}
+
+// Stop stops the consumer
+func (sc *StatusConsumer) Stop() {
+	close(sc.stopChan)
+	sc.eventBus.Unsubscribe(sc.eventChan)
+	sc.logger.Info("🌀 StatusConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (sc *StatusConsumer) Reset() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.tracking = false
+	sc.active = make(map[string]bool)
+	sc.lastAlerted = make(map[string]int64)
+}
+
+// consume processes TickEvents
+func (sc *StatusConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-sc.eventChan:
+			if !ok {
+				return
+			}
+			sc.processStatus(event)
+		case <-sc.stopChan:
+			return
+		}
+	}
+}
+
+// processStatus compares the status flags with the previous tick
+func (sc *StatusConsumer) processStatus(event events.TickEvent) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if state.Hero.Name == "" || state.Map.GameState == "" {
+		sc.tracking = false
+		return
+	}
+
+	// Dying clears every status; start over from a fresh baseline after respawn
+	if !state.Hero.Alive {
+		sc.tracking = false
+		return
+	}
+
+	for _, status := range heroStatuses {
+		active := status.Get(state.Hero)
+		wasActive := sc.active[status.Name]
+		sc.active[status.Name] = active
+
+		// First tick only sets the baseline
+		if !sc.tracking || active == wasActive {
+			continue
+		}
+
+		eventType := status.OnLoss
+		if active {
+			eventType = status.OnGain
+		}
+		if eventType == "" || !sc.isEventEnabled(eventType) {
+			continue
+		}
+
+		sc.alert(eventType, state.Map.ClockTime, map[string]interface{}{
+			"status": status.Name,
+			"active": active,
+		})
+	}
+
+	sc.tracking = true
+}
+
+// alert sends an event unless the same one went out less than its throttle ago
+func (sc *StatusConsumer) alert(eventType string, clockTime int64, data map[string]interface{}) {
+	throttle := sc.getTimingValue(eventType, "throttle", config.DefaultStatusThrottle)
+	if last, exists := sc.lastAlerted[eventType]; exists && clockTime-last < throttle {
+		return
+	}
+
+	sc.handleEvent(eventType, data)
+	sc.lastAlerted[eventType] = clockTime
+}
+
+// getTimingValue reads a numeric field of a status timing
+func (sc *StatusConsumer) getTimingValue(eventType, field string, fallback int64) int64 {
+	if sc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := sc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig(eventType); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if a status alert is enabled
+func (sc *StatusConsumer) isEventEnabled(eventType string) bool {
+	if sc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := sc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (sc *StatusConsumer) handleEvent(eventType string, data interface{}) {
+	sc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("🌀 Status event triggered")
+
+	for _, handler := range sc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
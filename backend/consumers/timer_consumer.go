@@ -0,0 +1,441 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"dota-gsi/backend/schedule"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Scheduler settings (live games only, replays are evaluated tick by tick)
+const (
+	SchedulerInterval = 100 * time.Millisecond // How often timers are checked between ticks
+	CatchUpTolerance  = 2.0                    // Seconds an "it's happening" alert may still fire after a missed tick
+	AlertLead         = 0.25                   // Seconds alerts fire early to cover speech latency
+)
+
+// MinuteInSeconds is the number of seconds in a minute
+const MinuteInSeconds int64 = 60
+
+// toInt64Safe safely converts interface{} to int64, handling multiple numeric types
+func toInt64Safe(val interface{}) (int64, bool) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float32:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// OccurrenceData returns extra event data for one occurrence of a timer
+type OccurrenceData func(rule schedule.Rule, occurrence int64) map[string]interface{}
+
+// TimerDefinition is a declarative timer: when it happens and how long
+// before each occurrence to announce it
+type TimerDefinition struct {
+	Key        string                 // Event type, also the timing and message key
+	Rule       schedule.Rule          // First occurrence, interval, end time or fixed times
+	Warnings   []int64                // Seconds before each occurrence to announce (0 = when it happens)
+	Data       map[string]interface{} // Extra event data
+	Occurrence OccurrenceData         // Extra per-occurrence event data (optional)
+}
+
+// builtinTimers are the schedule timings announced by the TimerConsumer.
+// Their rules come from the patch schedule under the same key unless Rule is set.
+var builtinTimers = []struct {
+	Key        string
+	Rule       string // Schedule rule key (default Key)
+	Warning    int64  // Fallback when warning_seconds is not configured
+	Data       map[string]interface{}
+	Occurrence OccurrenceData
+}{
+	{"bounty_rune", "", config.DefaultRuneWarning, map[string]interface{}{"rune_type": "bounty"}, nil},
+	{"power_rune", "", config.DefaultRuneWarning, map[string]interface{}{"rune_type": "power"}, nil},
+	{"water_rune", "", config.DefaultRuneWarning, map[string]interface{}{"rune_type": "water"}, nil},
+	{"wisdom_rune", "", config.DefaultRuneWarning, map[string]interface{}{"rune_type": "wisdom"}, nil},
+	{"catapult_timing", "", config.DefaultCatapultWarning, nil, nil},
+	{"stack_timing", "", config.DefaultStackWarning, nil, nil}, // Pull times (X:53 to stack at X:00)
+	{"lotus", "", config.DefaultLotusWarning, nil, nil},        // Healing Lotus grows at 3:00, then every 3min
+	{"outpost", "", config.DefaultOutpostWarning, nil, nil},    // Outposts grant XP at 10:00, then every 10min
+	// Day/night: warn before each transition, then announce it when it happens
+	{"day_night_cycle", "", config.DefaultDayNightWarning, nil, dayNightPhase},
+	{"day_night_transition", "day_night_cycle", 0, map[string]interface{}{"transition": true}, dayNightPhase},
+}
+
+// dayNightPhase tells which phase starts at an occurrence of the day/night
+// rule: the cycle starts with day and alternates every interval
+func dayNightPhase(rule schedule.Rule, occurrence int64) map[string]interface{} {
+	cycleType := "day"
+	if rule.Interval > 0 && ((occurrence-rule.First)/rule.Interval)%2 == 1 {
+		cycleType = "night"
+	}
+	return map[string]interface{}{"cycle_type": cycleType}
+}
+
+// TimerConsumer evaluates every timer definition (runes, catapults, stacks,
+// lotus, outposts, day/night and the user's custom timers) against the game clock. In
+// live games the clock is interpolated between ticks (see GameClock) and
+// checked by its own ticker, so alerts don't wait for the next tick.
+type TimerConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu           sync.Mutex       // Guards per-match state (Reset runs on the session goroutine)
+	alerted      map[string]int64 // "key@warning" -> occurrence already announced
+	lastGameTime int64
+	paused       bool              // Game was paused on the last tick
+	clock        GameClock         // Interpolated game clock
+	lastState    *events.GameState // Last in-progress tick (for the scheduler)
+	live         bool              // Last tick was live and the clock running
+}
+
+// NewTimerConsumer creates a new timer consumer
+func NewTimerConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *TimerConsumer {
+	return &TimerConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "timer", Policy: events.KeepLatest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+		alerted:    make(map[string]int64),
+	}
+}
+
+// Start begins consuming events
+func (tc *TimerConsumer) Start() {
+	go tc.consume()
+	tc.logger.Info("⏱️ TimerConsumer started")
+}
+
+// Stop stops the consumer
+func (tc *TimerConsumer) Stop() {
+	close(tc.stopChan)
+	tc.eventBus.Unsubscribe(tc.eventChan)
+	tc.logger.Info("⏱️ TimerConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (tc *TimerConsumer) Reset() {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.alerted = make(map[string]int64)
+	tc.lastGameTime = 0
+	tc.paused = false
+	tc.clock.Reset()
+	tc.lastState = nil
+	tc.live = false
+}
+
+// consume processes TickEvents
+func (tc *TimerConsumer) consume() {
+	ticker := time.NewTicker(SchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-tc.eventChan:
+			if !ok {
+				return
+			}
+			tc.processTimers(event)
+		case now := <-ticker.C:
+			tc.processClock(now)
+		case <-tc.stopChan:
+			return
+		}
+	}
+}
+
+// processTimers feeds the clock and announces every timer whose warning is due
+func (tc *TimerConsumer) processTimers(event events.TickEvent) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if tc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	clockTime := state.Map.ClockTime
+	if !state.Map.InProgress() {
+		tc.live = false
+		return
+	}
+
+	running := !state.Map.Paused
+	if tc.clock.Observe(clockTime, running, event.Time) && !event.Replayed {
+		tc.logger.WithFields(logrus.Fields{
+			"clock_time": clockTime,
+			"drift":      tc.clock.Drift(),
+		}).Debug("⏱️ Game clock rebased")
+	}
+	tc.lastState = state
+	tc.live = running && !event.Replayed
+
+	// Hold alerts while paused (they fire on resume if still due)
+	if state.Map.Paused {
+		tc.paused = true
+		return
+	}
+	if tc.paused {
+		tc.paused = false
+		tc.lastGameTime = -1 // Re-evaluate every timer at the resumed clock
+	}
+
+	// Live: evaluate at the interpolated clock right away
+	if tc.live {
+		if now, ok := tc.clock.Now(time.Now()); ok {
+			tc.evaluateAll(state, now)
+		}
+		return
+	}
+
+	// Replays run faster than real time, so only the reported seconds count
+	if clockTime == tc.lastGameTime {
+		return
+	}
+	tc.lastGameTime = clockTime
+	tc.evaluateAll(state, float64(clockTime))
+}
+
+// processClock announces due warnings between ticks (live games only)
+func (tc *TimerConsumer) processClock(at time.Time) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if !tc.live || tc.lastState == nil {
+		return
+	}
+	if now, ok := tc.clock.Now(at); ok {
+		tc.evaluateAll(tc.lastState, now)
+	}
+}
+
+// evaluateAll evaluates every enabled timer at a (possibly fractional) clock
+func (tc *TimerConsumer) evaluateAll(state *events.GameState, now float64) {
+	for _, def := range tc.definitions(state) {
+		if !tc.isEventEnabled(def.Key) {
+			continue
+		}
+		tc.evaluate(tc.applyTimingConfig(def), now)
+	}
+}
+
+// definitions returns the built-in timers of the active schedule followed by
+// the custom timers from config
+func (tc *TimerConsumer) definitions(state *events.GameState) []TimerDefinition {
+	activeRules := activeSchedule(tc.gameConfig, state)
+
+	definitions := make([]TimerDefinition, 0, len(builtinTimers))
+	for _, timer := range builtinTimers {
+		ruleKey := timer.Rule
+		if ruleKey == "" {
+			ruleKey = timer.Key
+		}
+		if rule, ok := activeRules.Rule(ruleKey); ok {
+			definitions = append(definitions, TimerDefinition{
+				Key:        timer.Key,
+				Rule:       rule,
+				Warnings:   []int64{timer.Warning},
+				Data:       timer.Data,
+				Occurrence: timer.Occurrence,
+			})
+		}
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetCustomTimers() []config.CustomTimer
+	}
+
+	if gc, ok := tc.gameConfig.(GameConfigInterface); ok {
+		for _, timer := range gc.GetCustomTimers() {
+			definitions = append(definitions, TimerDefinition{
+				Key: timer.Key,
+				Rule: schedule.Rule{
+					First:    timer.First,
+					Interval: timer.Interval,
+					Until:    timer.Until,
+				},
+				Warnings: timer.Warnings,
+				Data:     map[string]interface{}{"name": timer.Name},
+			})
+		}
+	}
+
+	return definitions
+}
+
+// applyTimingConfig applies the timing's config fields to a definition:
+// warning_seconds replaces the first warning. When a timing happens always
+// comes from the patch schedule (or the custom timer), never from config.
+func (tc *TimerConsumer) applyTimingConfig(def TimerDefinition) TimerDefinition {
+	if ws, ok := tc.getTimingValue(def.Key, "warning_seconds"); ok {
+		warnings := []int64{ws}
+		if len(def.Warnings) > 1 {
+			warnings = append(warnings, def.Warnings[1:]...)
+		}
+		def.Warnings = warnings
+	}
+	return def
+}
+
+// evaluate announces the upcoming occurrences of a timer once per warning.
+// Warnings fire AlertLead early; the "it's happening" warning (0) may still
+// fire up to CatchUpTolerance late when ticks were missed. When the clock
+// lands inside several warnings only the closest one is announced.
+func (tc *TimerConsumer) evaluate(def TimerDefinition, now float64) {
+	warnings := def.Warnings
+	if len(warnings) == 0 {
+		warnings = []int64{0}
+	}
+	widest := warnings[0]
+	for _, warning := range warnings {
+		if warning > widest {
+			widest = warning
+		}
+	}
+
+	// From the oldest occurrence that can still be caught up on to the last
+	// one inside the widest warning (a short interval can have several)
+	after := int64(math.Ceil(now-CatchUpTolerance)) - 1
+	for {
+		occurrence, ok := def.Rule.Next(after)
+		if !ok {
+			return
+		}
+		timeUntil := float64(occurrence) - now
+		if timeUntil > float64(widest)+AlertLead {
+			return
+		}
+		tc.evaluateOccurrence(def, warnings, occurrence, timeUntil, now)
+		after = occurrence
+	}
+}
+
+// evaluateOccurrence announces one occurrence if one of its warnings is due
+func (tc *TimerConsumer) evaluateOccurrence(def TimerDefinition, warnings []int64, occurrence int64, timeUntil, now float64) {
+	// Closest warning we're inside of (0 only fires once the occurrence is reached)
+	due := int64(-1)
+	for _, warning := range warnings {
+		if timeUntil > float64(warning)+AlertLead {
+			continue
+		}
+		if warning > 0 && timeUntil <= AlertLead {
+			continue
+		}
+		if due < 0 || warning < due {
+			due = warning
+		}
+	}
+	if due < 0 {
+		return
+	}
+	if last, exists := tc.alerted[alertKey(def.Key, due)]; exists && last == occurrence {
+		return
+	}
+
+	// The wider warnings of this occurrence are covered too
+	for _, warning := range warnings {
+		if warning >= due {
+			tc.alerted[alertKey(def.Key, warning)] = occurrence
+		}
+	}
+
+	seconds := int64(math.Round(timeUntil))
+	if seconds < 0 {
+		seconds = 0
+	}
+	data := map[string]interface{}{
+		"seconds":      seconds,
+		"spawn_time":   occurrence,
+		"minute":       occurrence / MinuteInSeconds,
+		"current_time": int64(math.Floor(now)),
+	}
+	for key, value := range def.Data {
+		data[key] = value
+	}
+	if def.Occurrence != nil {
+		for key, value := range def.Occurrence(def.Rule, occurrence) {
+			data[key] = value
+		}
+	}
+	tc.handleEvent(def.Key, data)
+}
+
+// alertKey identifies one warning of a timer
+func alertKey(key string, warning int64) string {
+	return fmt.Sprintf("%s@%d", key, warning)
+}
+
+// getTimingValue reads a numeric field of a timing
+func (tc *TimerConsumer) getTimingValue(key, field string) (int64, bool) {
+	if tc.gameConfig == nil {
+		return 0, false
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := tc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig(key); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				return toInt64Safe(val)
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// isEventEnabled checks if a timer is enabled
+func (tc *TimerConsumer) isEventEnabled(eventType string) bool {
+	if tc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := tc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled(eventType)
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (tc *TimerConsumer) handleEvent(eventType string, data interface{}) {
+	tc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("⏱️ Timer event triggered")
+
+	for _, handler := range tc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -0,0 +1,329 @@
+package consumers
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/handlers"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TormentorRespawn is how long a Tormentor takes to respawn after dying
+const TormentorRespawn int64 = 600
+
+// TormentorKillMergeWindow is how far apart (game seconds) a manual kill and
+// a GSI tormentor_killed event can be and still be the same kill
+const TormentorKillMergeWindow int64 = 30
+
+// tormentorRespawn is a pending respawn of one killed Tormentor
+type tormentorRespawn struct {
+	at      int64 // Clock of the respawn
+	manual  bool  // Recorded by MarkKilled (no GSI event yet)
+	merged  bool  // Manual kill and GSI event of the same death were combined
+	alerted bool  // Respawn already announced
+}
+
+// Tormentor events
+const (
+	EventTormentorSpawn   = "tormentor_spawn"
+	EventTormentorRespawn = "tormentor_respawn"
+)
+
+// TormentorConsumer warns before the Tormentors first spawn (at the minute in
+// the tormentor timing) and before each respawn. Kills come from the GSI
+// events block (tormentor_killed) or from MarkKilled.
+type TormentorConsumer struct {
+	logger     *logrus.Entry
+	eventBus   *events.EventBus
+	eventChan  <-chan events.TickEvent
+	stopChan   chan struct{}
+	handlers   []handlers.Handler
+	gameConfig interface{} // Game configuration (can be *config.GameConfig)
+
+	mu           sync.Mutex          // Guards per-match state (Reset runs on the session goroutine)
+	seenEvents   map[string]bool     // GSI events already handled (the block repeats them)
+	kills        int64               // Tormentor kills this match
+	respawns     []*tormentorRespawn // Pending respawns, one per killed Tormentor
+	spawnAlerted bool                // First spawn already announced
+	lastClock    int64
+	clockRunning bool // Clock of a running match has been seen
+}
+
+// NewTormentorConsumer creates a new Tormentor consumer
+func NewTormentorConsumer(eventBus *events.EventBus, logger *logrus.Entry, handlerList []handlers.Handler, gameConfig interface{}) *TormentorConsumer {
+	tc := &TormentorConsumer{
+		logger:     logger,
+		eventBus:   eventBus,
+		eventChan:  eventBus.SubscribeWith(events.SubscribeOptions{Name: "tormentor", Policy: events.DropOldest}),
+		stopChan:   make(chan struct{}),
+		handlers:   handlerList,
+		gameConfig: gameConfig,
+	}
+	tc.resetState()
+	return tc
+}
+
+// Start begins consuming events
+func (tc *TormentorConsumer) Start() {
+	go tc.consume()
+	tc.logger.Info("🗿 TormentorConsumer started")
+}
+
+// Stop stops the consumer
+func (tc *TormentorConsumer) Stop() {
+	close(tc.stopChan)
+	tc.eventBus.Unsubscribe(tc.eventChan)
+	tc.logger.Info("🗿 TormentorConsumer stopped")
+}
+
+// Reset clears per-match state (called by the session manager between matches)
+func (tc *TormentorConsumer) Reset() {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.resetState()
+}
+
+// resetState clears per-match state (caller holds mu)
+func (tc *TormentorConsumer) resetState() {
+	tc.seenEvents = make(map[string]bool)
+	tc.kills = 0
+	tc.respawns = nil
+	tc.spawnAlerted = false
+	tc.lastClock = 0
+	tc.clockRunning = false
+}
+
+// MarkKilled records a Tormentor kill at the current game clock (manual
+// trigger for clients without the events block)
+func (tc *TormentorConsumer) MarkKilled() (map[string]interface{}, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if !tc.clockRunning {
+		return nil, fmt.Errorf("no match in progress")
+	}
+	if tc.lastClock < tc.firstSpawn() {
+		return nil, fmt.Errorf("tormentor has not spawned yet")
+	}
+
+	tc.recordKill(tc.lastClock, "", true)
+	return tc.statusLocked(), nil
+}
+
+// Status returns the first spawn and pending respawn times
+func (tc *TormentorConsumer) Status() map[string]interface{} {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.statusLocked()
+}
+
+// consume processes TickEvents
+func (tc *TormentorConsumer) consume() {
+	for {
+		select {
+		case event, ok := <-tc.eventChan:
+			if !ok {
+				return
+			}
+			tc.processTormentor(event)
+		case <-tc.stopChan:
+			return
+		}
+	}
+}
+
+// processTormentor records kills and checks the spawn/respawn warnings
+func (tc *TormentorConsumer) processTormentor(event events.TickEvent) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if tc.eventBus.Stale(event.Generation) {
+		return // Tick of the previous match, queued before the reset
+	}
+
+	state := event.State
+	if !state.Map.InProgress() {
+		return
+	}
+
+	tc.lastClock = state.Map.ClockTime
+	tc.clockRunning = true
+
+	// Kills are recorded even while paused
+	tc.processGameEvents(state)
+
+	// Hold alerts while paused (they fire on resume if still due)
+	if state.Map.Paused || !tc.isEventEnabled() {
+		return
+	}
+
+	warningSeconds := tc.getWarningSeconds()
+	if !tc.spawnAlerted && tc.checkSpawn(EventTormentorSpawn, tc.firstSpawn(), state.Map.ClockTime, warningSeconds) {
+		tc.spawnAlerted = true
+	}
+
+	pending := tc.respawns[:0]
+	for _, respawn := range tc.respawns {
+		if !respawn.alerted && tc.checkSpawn(EventTormentorRespawn, respawn.at, state.Map.ClockTime, warningSeconds) {
+			respawn.alerted = true
+		}
+		if respawn.at > state.Map.ClockTime {
+			pending = append(pending, respawn)
+		}
+	}
+	tc.respawns = pending
+}
+
+// processGameEvents records tormentor_killed events
+func (tc *TormentorConsumer) processGameEvents(state *events.GameState) {
+	for _, gameEvent := range state.Events {
+		if gameEvent.EventType != events.GameEventTormentorKilled {
+			continue
+		}
+
+		key := fmt.Sprintf("%s@%d", gameEvent.EventType, gameEvent.GameTime)
+		if tc.seenEvents[key] {
+			continue
+		}
+		tc.seenEvents[key] = true
+
+		tc.recordKill(state.Map.ClockAt(gameEvent.GameTime), gameEvent.Team, false)
+	}
+}
+
+// recordKill schedules the respawn of a killed Tormentor (caller holds mu).
+// A manual kill and a GSI event within TormentorKillMergeWindow of each other
+// are the same death: the GSI time wins and the respawn is announced once.
+// Any other two kills are different Tormentors, however close in time.
+func (tc *TormentorConsumer) recordKill(clock int64, team string, manual bool) {
+	respawnAt := clock + TormentorRespawn
+	for _, respawn := range tc.respawns {
+		if respawn.merged || respawn.manual == manual || absInt64(respawn.at-respawnAt) > TormentorKillMergeWindow {
+			continue
+		}
+		if !manual {
+			respawn.at = respawnAt // The GSI event has the exact kill time
+			sort.Slice(tc.respawns, func(i, j int) bool { return tc.respawns[i].at < tc.respawns[j].at })
+		}
+		respawn.manual = false
+		respawn.merged = true
+		tc.logger.WithField("respawn", respawn.at).Debug("🗿 Manual and GSI kill merged")
+		return
+	}
+
+	tc.kills++
+	tc.respawns = append(tc.respawns, &tormentorRespawn{at: respawnAt, manual: manual})
+	sort.Slice(tc.respawns, func(i, j int) bool { return tc.respawns[i].at < tc.respawns[j].at })
+
+	tc.logger.WithFields(logrus.Fields{
+		"kills":      tc.kills,
+		"team":       team,
+		"clock_time": clock,
+		"respawn":    clock + TormentorRespawn,
+	}).Info("🗿 Tormentor killed")
+}
+
+// checkSpawn sends a spawn alert when it's within warningSeconds (the caller
+// dedupes). Returns true if the alert was sent.
+func (tc *TormentorConsumer) checkSpawn(eventType string, spawnTime, clock, warningSeconds int64) bool {
+	timeUntil := spawnTime - clock
+	if timeUntil <= 0 || timeUntil > warningSeconds {
+		return false
+	}
+
+	tc.handleEvent(eventType, map[string]interface{}{
+		"seconds":    timeUntil,
+		"spawn_time": spawnTime,
+	})
+	return true
+}
+
+// absInt64 returns the absolute value of n
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// statusLocked returns the current timers (caller holds mu)
+func (tc *TormentorConsumer) statusLocked() map[string]interface{} {
+	respawns := make([]int64, 0, len(tc.respawns))
+	for _, respawn := range tc.respawns {
+		respawns = append(respawns, respawn.at)
+	}
+
+	return map[string]interface{}{
+		"first_spawn": tc.firstSpawn(),
+		"kills":       tc.kills,
+		"respawns":    respawns,
+		"clock_time":  tc.lastClock,
+	}
+}
+
+// firstSpawn returns the clock of the first spawn ("time" is in minutes)
+func (tc *TormentorConsumer) firstSpawn() int64 {
+	return tc.getTimingValue("time", config.DefaultTormentorSpawn) * MinuteInSeconds
+}
+
+// getWarningSeconds returns the configured warning time
+func (tc *TormentorConsumer) getWarningSeconds() int64 {
+	return tc.getTimingValue("warning_seconds", config.DefaultTormentorWarning)
+}
+
+// getTimingValue reads a numeric field of the tormentor timing
+func (tc *TormentorConsumer) getTimingValue(field string, fallback int64) int64 {
+	if tc.gameConfig == nil {
+		return fallback
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		GetTimingConfig(string) map[string]interface{}
+	}
+
+	if gc, ok := tc.gameConfig.(GameConfigInterface); ok {
+		if cfg := gc.GetTimingConfig("tormentor"); cfg != nil {
+			if val, exists := cfg[field]; exists {
+				if converted, ok := toInt64Safe(val); ok {
+					return converted
+				}
+			}
+		}
+	}
+
+	return fallback
+}
+
+// isEventEnabled checks if the tormentor timing is enabled
+func (tc *TormentorConsumer) isEventEnabled() bool {
+	if tc.gameConfig == nil {
+		return true // Default to enabled if no config
+	}
+
+	// Type assertion to access GameConfig methods
+	type GameConfigInterface interface {
+		IsTimingEnabled(string) bool
+	}
+
+	if gc, ok := tc.gameConfig.(GameConfigInterface); ok {
+		return gc.IsTimingEnabled("tormentor")
+	}
+
+	return true // Default to enabled
+}
+
+// handleEvent sends event to all handlers
+func (tc *TormentorConsumer) handleEvent(eventType string, data interface{}) {
+	tc.logger.WithFields(logrus.Fields{
+		"event_type": eventType,
+		"data":       data,
+	}).Info("🗿 Tormentor event triggered")
+
+	for _, handler := range tc.handlers {
+		handler.Handle(eventType, data)
+	}
+}
@@ -2,61 +2,356 @@ package events
 
 import (
 	"dota-gsi/backend/metrics"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-// TickEvent represents a GSI tick with raw JSON for selective parsing
+// TickEvent represents a GSI tick with its raw JSON and decoded state
 type TickEvent struct {
-	RawJSON []byte    // Raw JSON from GSI - each consumer extracts what it needs
-	Time    time.Time // When the tick was received
+	RawJSON  []byte     // Raw JSON from GSI
+	Time     time.Time  // When the tick was received
+	Replayed bool       // True when the tick comes from a recording instead of Dota
+	State    *GameState // Decoded once per tick and shared by all consumers (read-only)
+
+	// Generation is stamped by the bus after the publish hooks ran (see
+	// NextGeneration). Consumers drop ticks of an older generation.
+	Generation uint64
+}
+
+// decode parses the raw JSON once before fan-out (no-op if already decoded)
+func (te *TickEvent) decode() {
+	if te.State == nil {
+		te.State = ParseGameState(te.RawJSON)
+	}
+}
+
+// PublishHook runs on the publishing goroutine before a tick reaches any
+// subscriber (keep it fast: every tick waits for it)
+type PublishHook func(event TickEvent)
+
+// publishHook wraps a hook so it can be found again for removal
+type publishHook struct {
+	fn PublishHook
+}
+
+// changeSubscriber receives ChangeEvents for a set of paths
+type changeSubscriber struct {
+	paths []string
+	ch    chan ChangeEvent
+
+	closeMu sync.RWMutex // Held for reading while sending (see subscriber)
+	closed  bool
+
+	delivered uint64
+	dropped   uint64
+}
+
+// deliver sends a change without blocking, dropping it if the buffer is full
+func (cs *changeSubscriber) deliver(change ChangeEvent) bool {
+	cs.closeMu.RLock()
+	defer cs.closeMu.RUnlock()
+
+	if cs.closed {
+		return true // Unsubscribed while the tick was being fanned out
+	}
+	select {
+	case cs.ch <- change:
+		atomic.AddUint64(&cs.delivered, 1)
+		return true
+	default:
+		atomic.AddUint64(&cs.dropped, 1)
+		metrics.Instance.IncrementDropped()
+		return false
+	}
+}
+
+// close closes the channel once no delivery is in progress
+func (cs *changeSubscriber) close() {
+	cs.closeMu.Lock()
+	defer cs.closeMu.Unlock()
+
+	if !cs.closed {
+		cs.closed = true
+		close(cs.ch)
+	}
+}
+
+// stats returns delivery stats in the same shape as tick subscribers
+func (cs *changeSubscriber) stats() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      "changes:" + strings.Join(cs.paths, ","),
+		"policy":    DropNewest.String(),
+		"delivered": atomic.LoadUint64(&cs.delivered),
+		"dropped":   atomic.LoadUint64(&cs.dropped),
+		"queued":    len(cs.ch),
+		"capacity":  cap(cs.ch),
+	}
 }
 
 // EventBus broadcasts TickEvents to multiple consumers
 type EventBus struct {
-	subscribers []chan TickEvent
-	mutex       sync.RWMutex
-	logger      *logrus.Entry
+	subscribers       []*subscriber
+	changeSubscribers []*changeSubscriber
+	hooks             []*publishHook
+	generation        uint64 // Current tick generation (atomic)
+	mutex             sync.RWMutex
+	logger            *logrus.Entry
 }
 
 // NewEventBus creates a new event bus
 func NewEventBus() *EventBus {
 	logger := logrus.WithField("component", "event-bus")
 	return &EventBus{
-		subscribers: make([]chan TickEvent, 0),
+		subscribers: make([]*subscriber, 0),
 		logger:      logger,
 	}
 }
 
-// Subscribe returns a channel to receive TickEvents
+// Subscribe returns a channel to receive TickEvents (default DropNewest policy)
 func (eb *EventBus) Subscribe() <-chan TickEvent {
+	return eb.SubscribeWith(SubscribeOptions{})
+}
+
+// SubscribeWith returns a channel to receive TickEvents with a backpressure policy
+func (eb *EventBus) SubscribeWith(opts SubscribeOptions) <-chan TickEvent {
 	eb.mutex.Lock()
 	defer eb.mutex.Unlock()
 
-	// Increased buffer size to reduce drops
-	ch := make(chan TickEvent, 100)
-	eb.subscribers = append(eb.subscribers, ch)
-	
-	return ch
+	sub := newSubscriber(opts)
+	eb.subscribers = append(eb.subscribers, sub)
+
+	return sub.ch
 }
 
-// Publish broadcasts a TickEvent to all subscribers
-func (eb *EventBus) Publish(event TickEvent) {
+// Unsubscribe removes a subscription and closes its channel
+func (eb *EventBus) Unsubscribe(ch <-chan TickEvent) {
+	eb.mutex.Lock()
+	var removed *subscriber
+	for i, sub := range eb.subscribers {
+		if (<-chan TickEvent)(sub.ch) == ch {
+			removed = sub
+			eb.subscribers = append(eb.subscribers[:i], eb.subscribers[i+1:]...)
+			break
+		}
+	}
+	eb.mutex.Unlock()
+
+	// Closed outside the mutex: a Block delivery in progress may hold it up
+	if removed != nil {
+		removed.close()
+	}
+}
+
+// SubscribeChanges returns a channel that receives a ChangeEvent every time
+// one of the fields at paths (gjson syntax, e.g. "map.daytime" or
+// "hero.alive") changes. Changes are rare, so they are sent without ever
+// blocking the publisher: a change that finds the buffer full is dropped and
+// counted in the dropped metric.
+func (eb *EventBus) SubscribeChanges(paths ...string) <-chan ChangeEvent {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	subscriber := &changeSubscriber{
+		paths: paths,
+		ch:    make(chan ChangeEvent, DefaultBufferSize),
+	}
+	eb.changeSubscribers = append(eb.changeSubscribers, subscriber)
+
+	return subscriber.ch
+}
+
+// UnsubscribeChanges removes a change subscription and closes its channel
+func (eb *EventBus) UnsubscribeChanges(ch <-chan ChangeEvent) {
+	eb.mutex.Lock()
+	var removed *changeSubscriber
+	for i, subscriber := range eb.changeSubscribers {
+		if (<-chan ChangeEvent)(subscriber.ch) == ch {
+			removed = subscriber
+			eb.changeSubscribers = append(eb.changeSubscribers[:i], eb.changeSubscribers[i+1:]...)
+			break
+		}
+	}
+	eb.mutex.Unlock()
+
+	if removed != nil {
+		removed.close()
+	}
+}
+
+// OnPublish registers a hook that sees every tick before it is fanned out.
+// Returns a function that removes the hook.
+func (eb *EventBus) OnPublish(fn PublishHook) func() {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	hook := &publishHook{fn: fn}
+	eb.hooks = append(eb.hooks, hook)
+
+	return func() {
+		eb.mutex.Lock()
+		defer eb.mutex.Unlock()
+
+		for i, h := range eb.hooks {
+			if h == hook {
+				eb.hooks = append(eb.hooks[:i], eb.hooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// runHooks calls every publish hook (outside the mutex, hooks may take a while)
+func (eb *EventBus) runHooks(event TickEvent) {
+	eb.mutex.RLock()
+	hooks := make([]*publishHook, len(eb.hooks))
+	copy(hooks, eb.hooks)
+	eb.mutex.RUnlock()
+
+	for _, hook := range hooks {
+		hook.fn(event)
+	}
+}
+
+// NextGeneration starts a new tick generation (e.g. when a new match starts).
+// Ticks published from then on carry the new number, so consumers can tell
+// them from ticks of the previous match still queued in their buffers.
+func (eb *EventBus) NextGeneration() uint64 {
+	return atomic.AddUint64(&eb.generation, 1)
+}
+
+// Stale checks if a tick belongs to an older generation than the current one.
+// Consumers check it under the lock their Reset takes, so a stale tick can
+// never bring back state that was just cleared.
+func (eb *EventBus) Stale(generation uint64) bool {
+	return generation < atomic.LoadUint64(&eb.generation)
+}
+
+// Stats returns delivery and lag stats for every subscriber
+func (eb *EventBus) Stats() []map[string]interface{} {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	stats := make([]map[string]interface{}, 0, len(eb.subscribers)+len(eb.changeSubscribers))
+	for _, sub := range eb.subscribers {
+		stats = append(stats, sub.stats())
+	}
+	for _, subscriber := range eb.changeSubscribers {
+		stats = append(stats, subscriber.stats())
+	}
+	return stats
+}
+
+// deliveryOrder copies the subscriber list for delivery outside the mutex,
+// with Block subscribers last so their waits never delay the others
+func (eb *EventBus) deliveryOrder() []*subscriber {
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	ordered := make([]*subscriber, 0, len(eb.subscribers))
+	for _, sub := range eb.subscribers {
+		if sub.policy != Block {
+			ordered = append(ordered, sub)
+		}
+	}
+	for _, sub := range eb.subscribers {
+		if sub.policy == Block {
+			ordered = append(ordered, sub)
+		}
+	}
+	return ordered
+}
+
+// WaitDrained waits up to timeout until every subscriber has taken all the
+// ticks and changes queued for it (used when a replay ends, before stopping
+// the consumers). Returns false on timeout.
+func (eb *EventBus) WaitDrained(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if eb.queued() == 0 {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// queued returns how many ticks and changes wait in all the buffers
+func (eb *EventBus) queued() int {
 	eb.mutex.RLock()
 	defer eb.mutex.RUnlock()
 
-	// Broadcast with metrics tracking
-	for _, subscriber := range eb.subscribers {
-		select {
-		case subscriber <- event:
-			// Event delivered successfully
-			metrics.Instance.IncrementProcessed()
-		default:
-			// Channel full, log and track
-			eb.logger.Warn("Event dropped - channel buffer full")
-			metrics.Instance.IncrementDropped()
+	total := 0
+	for _, sub := range eb.subscribers {
+		total += len(sub.ch)
+	}
+	for _, subscriber := range eb.changeSubscribers {
+		total += len(subscriber.ch)
+	}
+	return total
+}
+
+// Publish broadcasts a TickEvent to all subscribers following their policies.
+// Delivery runs outside the mutex, so a waiting Block subscriber doesn't hold
+// up Subscribe/Unsubscribe or other publishers.
+func (eb *EventBus) Publish(event TickEvent) {
+	event.decode()
+	eb.runHooks(event)
+	event.Generation = atomic.LoadUint64(&eb.generation)
+
+	for _, sub := range eb.deliveryOrder() {
+		if !sub.deliver(event, 0) && sub.policy != KeepLatest {
+			eb.logger.WithFields(logrus.Fields{
+				"subscriber": sub.name,
+				"policy":     sub.policy.String(),
+			}).Warn("Event dropped - channel buffer full")
+		}
+	}
+
+	eb.publishChanges(event)
+}
+
+// PublishWait broadcasts a TickEvent, waiting up to timeout per subscriber for
+// buffer space whatever its policy (used by replays, which can publish much
+// faster than consumers drain and must not skip ticks)
+func (eb *EventBus) PublishWait(event TickEvent, timeout time.Duration) {
+	event.decode()
+	eb.runHooks(event)
+	event.Generation = atomic.LoadUint64(&eb.generation)
+
+	for _, sub := range eb.deliveryOrder() {
+		if !sub.deliver(event, timeout) {
+			eb.logger.WithField("subscriber", sub.name).Warn("Event dropped - subscriber did not drain in time")
+		}
+	}
+
+	eb.publishChanges(event)
+}
+
+// publishChanges sends ChangeEvents to subscribers whose fields changed
+// this tick (never waits for room)
+func (eb *EventBus) publishChanges(event TickEvent) {
+	eb.mutex.RLock()
+	subscribers := make([]*changeSubscriber, len(eb.changeSubscribers))
+	copy(subscribers, eb.changeSubscribers)
+	eb.mutex.RUnlock()
+
+	for _, subscriber := range subscribers {
+		for _, path := range subscriber.paths {
+			change, ok := event.State.Change(path)
+			if !ok {
+				continue
+			}
+
+			changeEvent := ChangeEvent{Change: change, Time: event.Time, State: event.State, Generation: event.Generation}
+			if !subscriber.deliver(changeEvent) {
+				eb.logger.WithField("path", path).Warn("Change event dropped - channel buffer full")
+			}
 		}
 	}
 }
@@ -66,8 +361,13 @@ func (eb *EventBus) Close() {
 	eb.mutex.Lock()
 	defer eb.mutex.Unlock()
 
-	for _, subscriber := range eb.subscribers {
-		close(subscriber)
+	for _, sub := range eb.subscribers {
+		sub.close()
 	}
 	eb.subscribers = nil
+
+	for _, subscriber := range eb.changeSubscribers {
+		subscriber.close()
+	}
+	eb.changeSubscribers = nil
 }
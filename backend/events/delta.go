@@ -0,0 +1,135 @@
+package events
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// ============================================================================
+// Tick Deltas
+// ============================================================================
+// Dota sends a "previously" block with the old value of every field that
+// changed since the last tick, and an "added" block with fields that just
+// appeared. These helpers expose them so consumers can react to changes
+// without keeping their own shadow state.
+//
+// A change is only visible on the tick that carries it: consumers calling
+// Change/Changed should subscribe with DropOldest, which only loses a change
+// when the consumer falls a full buffer behind (KeepLatest and DropNewest
+// skip ticks much sooner). EventBus.SubscribeChanges delivers only the changes
+// of a few paths, so a consumer that watches a handful of fields (like the
+// MapConsumer) doesn't need a tick subscription at all.
+
+// Change describes a field that changed this tick
+type Change struct {
+	Path     string       `json:"path"`
+	Previous gjson.Result `json:"-"` // Old value (does not exist for added fields)
+	Current  gjson.Result `json:"-"` // New value (does not exist for removed fields)
+	Added    bool         `json:"added"`
+}
+
+// ChangeEvent is delivered to change subscribers
+type ChangeEvent struct {
+	Change
+	Time       time.Time  // When the tick was received
+	State      *GameState // Full state of the tick (read-only)
+	Generation uint64     // Generation of the tick (see EventBus.NextGeneration)
+}
+
+// Changed checks if the field at path (or anything below it) changed this tick
+func (gs *GameState) Changed(path string) bool {
+	_, ok := gs.Change(path)
+	return ok
+}
+
+// Change returns the old and new value of the field at path
+func (gs *GameState) Change(path string) (Change, bool) {
+	change := Change{
+		Path:    path,
+		Current: gs.Get(path),
+	}
+
+	previously := gjson.ParseBytes(gs.Previously)
+	if previous := previously.Get(path); previous.Exists() {
+		change.Previous = previous
+		return change, true
+	}
+
+	added := gjson.ParseBytes(gs.Added)
+	if added.Get(path).Exists() {
+		change.Added = true
+		return change, true
+	}
+
+	// A whole block may be flagged (e.g. "previously": {"hero": true} or
+	// "added": {"abilities": {"ability3": true}})
+	parts := strings.Split(path, ".")
+	for i := len(parts) - 1; i > 0; i-- {
+		ancestorPath := strings.Join(parts[:i], ".")
+		if ancestor := added.Get(ancestorPath); ancestor.Exists() && !ancestor.IsObject() {
+			change.Added = true
+			return change, true
+		}
+		if ancestor := previously.Get(ancestorPath); ancestor.Exists() && !ancestor.IsObject() {
+			return change, true
+		}
+	}
+
+	return Change{}, false
+}
+
+// Changes returns every leaf field that changed this tick, sorted by path
+func (gs *GameState) Changes() []Change {
+	paths := make(map[string]bool)
+	collectLeafPaths(gjson.ParseBytes(gs.Previously), "", paths)
+	collectLeafPaths(gjson.ParseBytes(gs.Added), "", paths)
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	changes := make([]Change, 0, len(sorted))
+	for _, path := range sorted {
+		if change, ok := gs.Change(path); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// collectLeafPaths adds the gjson path of every non-object value below result
+func collectLeafPaths(result gjson.Result, prefix string, paths map[string]bool) {
+	if !result.IsObject() {
+		if prefix != "" {
+			paths[prefix] = true
+		}
+		return
+	}
+
+	result.ForEach(func(key, value gjson.Result) bool {
+		path := escapePathKey(key.String())
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		collectLeafPaths(value, path, paths)
+		return true
+	})
+}
+
+// escapePathKey escapes gjson special characters in a single key
+func escapePathKey(key string) string {
+	var builder strings.Builder
+	for _, r := range key {
+		switch r {
+		case '.', '*', '?', '|', '#', '@', '\\':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,273 @@
+package events
+
+import (
+	"dota-gsi/backend/metrics"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// ============================================================================
+// Game State
+// ============================================================================
+// Typed snapshot of a GSI tick. It is decoded once per tick (by the GSI server
+// or the EventBus) and shared read-only by every consumer, so consumers get
+// compile-time checked fields instead of hand-written gjson paths.
+
+// Dota game rules states (map.game_state)
+const (
+	GameStateHeroSelection = "DOTA_GAMERULES_STATE_HERO_SELECTION"
+	GameStateStrategyTime  = "DOTA_GAMERULES_STATE_STRATEGY_TIME"
+	GameStatePreGame       = "DOTA_GAMERULES_STATE_PRE_GAME"
+	GameStateInProgress    = "DOTA_GAMERULES_STATE_GAME_IN_PROGRESS"
+	GameStatePostGame      = "DOTA_GAMERULES_STATE_POST_GAME"
+)
+
+// Game event types (events block)
+const (
+	GameEventRoshanKilled    = "roshan_killed"
+	GameEventAegisPickedUp   = "aegis_picked_up"
+	GameEventAegisDenied     = "aegis_denied"
+	GameEventTormentorKilled = "tormentor_killed"
+)
+
+// GameState is the decoded content of a GSI tick (treat as read-only)
+type GameState struct {
+	Provider   Provider                       `json:"provider"`
+	Map        Map                            `json:"map"`
+	Player     Player                         `json:"player"`
+	Hero       Hero                           `json:"hero"`
+	Abilities  map[string]Ability             `json:"abilities"` // ability0, ability1, ...
+	Items      map[string]Item                `json:"items"`     // slot0-8, stash0-5, teleport0, neutral0
+	Buildings  map[string]map[string]Building `json:"buildings"` // team ("radiant"/"dire") -> building name
+	Draft      Draft                          `json:"draft"`
+	Auth       Auth                           `json:"auth"`
+	Events     []GameEvent                    `json:"events"`     // Recent game events (roshan, aegis, tormentor...)
+	Previously json.RawMessage                `json:"previously"` // Old values of fields that changed this tick
+	Added      json.RawMessage                `json:"added"`      // Fields that appeared this tick
+
+	raw []byte // Full tick JSON for dynamic path lookups
+}
+
+// Provider holds the provider block (game and client version)
+type Provider struct {
+	Name      string `json:"name"`
+	AppID     int64  `json:"appid"`
+	Version   int64  `json:"version"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Map holds the map block (clock, day/night, score, game state)
+type Map struct {
+	Name                 string `json:"name"`
+	MatchID              string `json:"matchid"`
+	GameTime             int64  `json:"game_time"`
+	ClockTime            int64  `json:"clock_time"`
+	Daytime              bool   `json:"daytime"`
+	NightstalkerNight    bool   `json:"nightstalker_night"`
+	RadiantScore         int64  `json:"radiant_score"`
+	DireScore            int64  `json:"dire_score"`
+	GameState            string `json:"game_state"`
+	Paused               bool   `json:"paused"`
+	WinTeam              string `json:"win_team"`
+	CustomGameName       string `json:"customgamename"`
+	WardPurchaseCooldown int64  `json:"ward_purchase_cooldown"`
+	GlyphCooldown        int64  `json:"glyph_cooldown"`         // Player's team (player data)
+	RadiantGlyphCooldown int64  `json:"radiant_glyph_cooldown"` // Spectator data
+	DireGlyphCooldown    int64  `json:"dire_glyph_cooldown"`    // Spectator data
+}
+
+// Player holds the player block (KDA, gold, farm)
+type Player struct {
+	SteamID            string `json:"steamid"`
+	AccountID          string `json:"accountid"`
+	Name               string `json:"name"`
+	Activity           string `json:"activity"`
+	Kills              int64  `json:"kills"`
+	Deaths             int64  `json:"deaths"`
+	Assists            int64  `json:"assists"`
+	LastHits           int64  `json:"last_hits"`
+	Denies             int64  `json:"denies"`
+	KillStreak         int64  `json:"kill_streak"`
+	CommandsIssued     int64  `json:"commands_issued"`
+	TeamName           string `json:"team_name"`
+	Gold               int64  `json:"gold"`
+	GoldReliable       int64  `json:"gold_reliable"`
+	GoldUnreliable     int64  `json:"gold_unreliable"`
+	GoldFromHeroKills  int64  `json:"gold_from_hero_kills"`
+	GoldFromCreepKills int64  `json:"gold_from_creep_kills"`
+	GoldFromIncome     int64  `json:"gold_from_income"`
+	GoldFromShared     int64  `json:"gold_from_shared"`
+	GPM                int64  `json:"gpm"`
+	XPM                int64  `json:"xpm"`
+}
+
+// Hero holds the hero block (vitals, respawn, buyback, status effects)
+type Hero struct {
+	XPos            int64  `json:"xpos"`
+	YPos            int64  `json:"ypos"`
+	ID              int64  `json:"id"`
+	Name            string `json:"name"`
+	Level           int64  `json:"level"`
+	XP              int64  `json:"xp"`
+	Alive           bool   `json:"alive"`
+	RespawnSeconds  int64  `json:"respawn_seconds"`
+	BuybackCost     int64  `json:"buyback_cost"`
+	BuybackCooldown int64  `json:"buyback_cooldown"`
+	Health          int64  `json:"health"`
+	MaxHealth       int64  `json:"max_health"`
+	HealthPercent   int64  `json:"health_percent"`
+	Mana            int64  `json:"mana"`
+	MaxMana         int64  `json:"max_mana"`
+	ManaPercent     int64  `json:"mana_percent"`
+	Silenced        bool   `json:"silenced"`
+	Stunned         bool   `json:"stunned"`
+	Disarmed        bool   `json:"disarmed"`
+	MagicImmune     bool   `json:"magicimmune"`
+	Hexed           bool   `json:"hexed"`
+	Muted           bool   `json:"muted"`
+	Break           bool   `json:"break"`
+	AghanimsScepter bool   `json:"aghanims_scepter"`
+	AghanimsShard   bool   `json:"aghanims_shard"`
+	Smoked          bool   `json:"smoked"`
+	HasDebuff       bool   `json:"has_debuff"`
+}
+
+// Ability holds one abilities.abilityN block
+type Ability struct {
+	Name           string `json:"name"`
+	Level          int64  `json:"level"`
+	CanCast        bool   `json:"can_cast"`
+	Passive        bool   `json:"passive"`
+	AbilityActive  bool   `json:"ability_active"`
+	Cooldown       int64  `json:"cooldown"`
+	Ultimate       bool   `json:"ultimate"`
+	Charges        int64  `json:"charges"`
+	MaxCharges     int64  `json:"max_charges"`
+	ChargeCooldown int64  `json:"charge_cooldown"`
+}
+
+// Item holds one items.* block (name is "empty" for free slots)
+type Item struct {
+	Name      string `json:"name"`
+	Purchaser int64  `json:"purchaser"`
+	CanCast   bool   `json:"can_cast"`
+	Cooldown  int64  `json:"cooldown"`
+	Passive   bool   `json:"passive"`
+	Charges   int64  `json:"charges"`
+	ItemLevel int64  `json:"item_level"`
+}
+
+// Building holds one buildings.<team>.<name> block
+type Building struct {
+	Health    int64 `json:"health"`
+	MaxHealth int64 `json:"max_health"`
+}
+
+// Draft holds the draft block (hero selection in captains mode / ranked)
+type Draft struct {
+	ActiveTeam              int64     `json:"activeteam"` // 2 = radiant, 3 = dire
+	Pick                    bool      `json:"pick"`       // false while banning
+	ActiveTeamTimeRemaining int64     `json:"activeteam_time_remaining"`
+	RadiantBonusTime        int64     `json:"radiant_bonus_time"`
+	DireBonusTime           int64     `json:"dire_bonus_time"`
+	Team2                   DraftTeam `json:"team2"`
+	Team3                   DraftTeam `json:"team3"`
+}
+
+// DraftTeam holds one team's picks and bans
+type DraftTeam struct {
+	HomeTeam bool
+	Picks    []DraftHero
+	Bans     []DraftHero
+}
+
+// DraftHero is a picked or banned hero
+type DraftHero struct {
+	ID    int64
+	Class string
+}
+
+// GameEvent holds one entry of the events block
+type GameEvent struct {
+	GameTime       int64  `json:"game_time"` // map.game_time when it happened (not clock_time)
+	EventType      string `json:"event_type"`
+	Team           string `json:"team"`
+	KillerPlayerID int64  `json:"killer_player_id"`
+	PlayerID       int64  `json:"player_id"`
+	Snatched       bool   `json:"snatched"`
+}
+
+// Auth holds the auth block written into the GSI cfg
+type Auth struct {
+	Token string `json:"token"`
+}
+
+// UnmarshalJSON decodes the flat pickN_id/pickN_class/banN_id/banN_class keys
+func (dt *DraftTeam) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if raw, ok := fields["home_team"]; ok {
+		json.Unmarshal(raw, &dt.HomeTeam)
+	}
+	dt.Picks = decodeDraftHeroes(fields, "pick")
+	dt.Bans = decodeDraftHeroes(fields, "ban")
+	return nil
+}
+
+// decodeDraftHeroes collects prefixN_id/prefixN_class pairs in order
+func decodeDraftHeroes(fields map[string]json.RawMessage, prefix string) []DraftHero {
+	heroes := make([]DraftHero, 0)
+	for i := 0; ; i++ {
+		rawID, ok := fields[fmt.Sprintf("%s%d_id", prefix, i)]
+		if !ok {
+			return heroes
+		}
+
+		var hero DraftHero
+		json.Unmarshal(rawID, &hero.ID)
+		if rawClass, ok := fields[fmt.Sprintf("%s%d_class", prefix, i)]; ok {
+			json.Unmarshal(rawClass, &hero.Class)
+		}
+		heroes = append(heroes, hero)
+	}
+}
+
+// ParseGameState decodes a raw GSI tick. Decoding is best effort: fields with
+// unexpected types (e.g. spectator blocks keyed by team) are left empty.
+func ParseGameState(raw []byte) *GameState {
+	start := time.Now()
+
+	state := &GameState{raw: raw}
+	json.Unmarshal(raw, state)
+
+	// Track parse time for metrics
+	metrics.Instance.AddParseTime(time.Since(start))
+
+	return state
+}
+
+// Get retrieves any value from the raw tick by gjson path (for fields not typed above)
+func (gs *GameState) Get(path string) gjson.Result {
+	return gjson.GetBytes(gs.raw, path)
+}
+
+// Has checks if a block or field is present in the tick
+func (gs *GameState) Has(path string) bool {
+	return gs.Get(path).Exists()
+}
+
+// ClockAt converts an event's game_time to the match clock (map.clock_time)
+func (m Map) ClockAt(gameTime int64) int64 {
+	return gameTime - (m.GameTime - m.ClockTime)
+}
+
+// InProgress checks if the match clock is running (horn has sounded)
+func (m Map) InProgress() bool {
+	return m.GameState == GameStateInProgress
+}
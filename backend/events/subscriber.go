@@ -0,0 +1,252 @@
+package events
+
+import (
+	"dota-gsi/backend/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// Subscribers and Backpressure
+// ============================================================================
+// Each subscriber picks what happens when its buffer is full, so slow
+// consumers can't hold back the others and timers never act on stale ticks.
+
+// Policy decides what happens to a tick when a subscriber's buffer is full
+type Policy int
+
+const (
+	// DropNewest drops the incoming tick (default)
+	DropNewest Policy = iota
+	// DropOldest drops the oldest queued tick to make room for the new one
+	DropOldest
+	// KeepLatest keeps only the most recent tick (buffer of 1)
+	KeepLatest
+	// Block waits up to SubscribeOptions.Timeout for room, then drops the tick
+	Block
+)
+
+// Default subscriber settings
+const (
+	DefaultBufferSize   = 100
+	DefaultBlockTimeout = time.Second
+)
+
+// String returns the policy name used in logs and /health
+func (p Policy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop_oldest"
+	case KeepLatest:
+		return "keep_latest"
+	case Block:
+		return "block"
+	default:
+		return "drop_newest"
+	}
+}
+
+// SubscribeOptions configures a subscription
+type SubscribeOptions struct {
+	Name    string        // Shown in stats (e.g. "timer", "recorder")
+	Policy  Policy        // What to do when the buffer is full
+	Buffer  int           // Buffer size (default 100, always 1 for KeepLatest)
+	Timeout time.Duration // How long Block waits for room (default 1s)
+}
+
+// subscriber is a single TickEvent subscription
+type subscriber struct {
+	name    string
+	policy  Policy
+	timeout time.Duration
+	ch      chan TickEvent
+
+	// Delivery happens outside the bus mutex: sends hold closeMu for reading
+	// so closing the channel never races a send
+	closeMu sync.RWMutex
+	closed  bool
+
+	delivered uint64
+	dropped   uint64
+
+	// Lag tracking: when each queued tick entered the buffer (ring indexed by
+	// send count, sized like the buffer so queued ticks are never overwritten)
+	lagMu     sync.Mutex
+	enqueued  []time.Time
+	sent      uint64
+	maxQueued int
+	maxLag    time.Duration
+}
+
+// newSubscriber creates a subscriber, filling in option defaults
+func newSubscriber(opts SubscribeOptions) *subscriber {
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = DefaultBufferSize
+	}
+	if opts.Policy == KeepLatest {
+		buffer = 1
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultBlockTimeout
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "anonymous"
+	}
+
+	return &subscriber{
+		name:     name,
+		policy:   opts.Policy,
+		timeout:  timeout,
+		ch:       make(chan TickEvent, buffer),
+		enqueued: make([]time.Time, buffer),
+	}
+}
+
+// deliver sends a tick following the subscriber's policy. When wait > 0
+// (PublishWait) it waits up to wait for room whatever the policy is.
+// Returns false if the tick was dropped.
+func (s *subscriber) deliver(event TickEvent, wait time.Duration) bool {
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+
+	if s.closed {
+		return true // Unsubscribed while the tick was being fanned out
+	}
+	if s.trySend(event) {
+		return true
+	}
+
+	if wait > 0 {
+		return s.sendWithin(event, wait)
+	}
+
+	switch s.policy {
+	case DropOldest, KeepLatest:
+		// Make room by discarding the oldest queued tick. The consumer may
+		// drain concurrently, so retry a few times before giving up.
+		for attempt := 0; attempt < 3; attempt++ {
+			select {
+			case <-s.ch:
+				s.drop()
+			default:
+			}
+			if s.trySend(event) {
+				return true
+			}
+		}
+		s.drop()
+		return false
+	case Block:
+		return s.sendWithin(event, s.timeout)
+	default:
+		s.drop()
+		return false
+	}
+}
+
+// close closes the channel once no delivery is in progress
+func (s *subscriber) close() {
+	s.closeMu.Lock()
+	defer s.closeMu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+// trySend sends without blocking
+func (s *subscriber) trySend(event TickEvent) bool {
+	select {
+	case s.ch <- event:
+		s.deliverOK()
+		return true
+	default:
+		return false
+	}
+}
+
+// sendWithin waits up to timeout for room in the buffer
+func (s *subscriber) sendWithin(event TickEvent, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case s.ch <- event:
+		s.deliverOK()
+		return true
+	case <-timer.C:
+		s.drop()
+		return false
+	}
+}
+
+// deliverOK records a delivered tick, the queue high-water mark and the
+// highest lag seen
+func (s *subscriber) deliverOK() {
+	atomic.AddUint64(&s.delivered, 1)
+	metrics.Instance.IncrementProcessed()
+
+	s.lagMu.Lock()
+	defer s.lagMu.Unlock()
+
+	s.enqueued[s.sent%uint64(len(s.enqueued))] = time.Now()
+	s.sent++
+
+	if queued := len(s.ch); queued > s.maxQueued {
+		s.maxQueued = queued
+	}
+	if lag := s.lagLocked(); lag > s.maxLag {
+		s.maxLag = lag
+	}
+}
+
+// lagLocked returns how long the oldest queued tick has been waiting for the
+// consumer, 0 when the buffer is empty (caller holds lagMu)
+func (s *subscriber) lagLocked() time.Duration {
+	queued := uint64(len(s.ch))
+	if queued > s.sent {
+		queued = s.sent // Sent but not recorded yet
+	}
+	if queued == 0 {
+		return 0
+	}
+
+	oldest := s.enqueued[(s.sent-queued)%uint64(len(s.enqueued))]
+	return time.Since(oldest)
+}
+
+// drop records a dropped tick
+func (s *subscriber) drop() {
+	atomic.AddUint64(&s.dropped, 1)
+	metrics.Instance.IncrementDropped()
+}
+
+// stats returns delivery and lag stats for this subscriber
+func (s *subscriber) stats() map[string]interface{} {
+	s.lagMu.Lock()
+	defer s.lagMu.Unlock()
+
+	lag := s.lagLocked()
+	if lag > s.maxLag {
+		s.maxLag = lag
+	}
+
+	return map[string]interface{}{
+		"name":       s.name,
+		"policy":     s.policy.String(),
+		"delivered":  atomic.LoadUint64(&s.delivered),
+		"dropped":    atomic.LoadUint64(&s.dropped),
+		"queued":     len(s.ch), // Ticks waiting to be consumed (queue depth)
+		"max_queued": s.maxQueued,
+		"capacity":   cap(s.ch),
+		"lag_ms":     lag.Milliseconds(), // Age of the oldest queued tick
+		"max_lag_ms": s.maxLag.Milliseconds(),
+	}
+}
@@ -19,16 +19,19 @@ const (
 	EventScoreChange     = "score_change"
 
 	// Hero events
-	EventHeroHealthLow = "hero_health_low"
-	EventHeroManaLow   = "hero_mana_low"
-	EventHeroDeath     = "hero_death"
-	EventHeroLevelUp   = "hero_level_up"
+	EventHeroHealthLow      = "hero_health_low"
+	EventHeroHealthCritical = "hero_health_critical"
+	EventHeroManaLow        = "hero_mana_low"
+	EventHeroDeath          = "hero_death"
+	EventHeroLevelUp        = "hero_level_up"
 
 	// Abilities events
 	EventUltimateReady   = "ultimate_ready"
 	EventAbilityCooldown = "ability_cooldown"
+	EventAbilityReady    = "ability_ready"
 
 	// Items events
 	EventTPReady       = "tp_ready"
 	EventItemPurchased = "item_purchased"
+	EventItemReady     = "item_ready"
 )
@@ -23,6 +23,7 @@ import (
 // AudioEvent represents an audio event to be played by the frontend
 type AudioEvent struct {
 	Filename  string                 `json:"filename"`
+	Text      string                 `json:"text,omitempty"` // Spoken by the frontend's system voice when there is no audio file
 	EventType string                 `json:"eventType"`
 	Data      map[string]interface{} `json:"data"`
 	Timestamp int64                  `json:"timestamp"`
@@ -193,7 +194,7 @@ func (vh *VoiceHandler) speakWithData(text string, eventType string, data interf
 		// FREE MODE: Use embedded audio files
 		if vh.mode == "free" {
 			// Use generic embedded audio file (e.g., "power_rune_warning.mp3")
-			embeddedFilename := vh.getEmbeddedFilename(eventType)
+			embeddedFilename := vh.getEmbeddedFilename(eventType, data)
 			
 			// Check if embedded file exists
 			if assets.HasAudioFile(embeddedFilename) {
@@ -205,7 +206,10 @@ func (vh *VoiceHandler) speakWithData(text string, eventType string, data interf
 				return
 			}
 			
-			vh.logger.WithField("filename", embeddedFilename).Warn("Embedded audio not found for free mode")
+			// No recording for this event (custom timers, rules, scripts...):
+			// the frontend speaks the text with the system voice instead
+			vh.logger.WithField("filename", embeddedFilename).Debug("No embedded audio, using system voice (free mode)")
+			vh.emitSpeechEvent(text, eventType, data)
 			return
 		}
 
@@ -242,7 +246,16 @@ func (vh *VoiceHandler) speakWithData(text string, eventType string, data interf
 }
 
 // getEmbeddedFilename returns the embedded audio filename for an event type
-func (vh *VoiceHandler) getEmbeddedFilename(eventType string) string {
+func (vh *VoiceHandler) getEmbeddedFilename(eventType string, data interface{}) string {
+	// Respawn countdown speaks the number itself: one file per second
+	if eventType == "hero_respawn_countdown" {
+		if dataMap, ok := data.(map[string]interface{}); ok {
+			if seconds, exists := dataMap["seconds"]; exists {
+				return fmt.Sprintf("%s_%v_warning.mp3", eventType, seconds)
+			}
+		}
+	}
+
 	// Remove any suffixes and add _warning.mp3
 	baseType := strings.TrimSuffix(eventType, "_warning")
 	baseType = strings.TrimSuffix(baseType, "_spawned")
@@ -364,6 +377,27 @@ func (vh *VoiceHandler) emitAudioEvent(filename, eventType string, data interfac
 	}
 }
 
+// emitSpeechEvent asks the frontend to speak text with the system voice
+func (vh *VoiceHandler) emitSpeechEvent(text, eventType string, data interface{}) {
+	dataMap := make(map[string]interface{})
+	if m, ok := data.(map[string]interface{}); ok {
+		dataMap = m
+	}
+
+	event := AudioEvent{
+		Text:      text,
+		EventType: eventType,
+		Data:      dataMap,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if vh.directEmitter != nil {
+		vh.directEmitter("audio:play", event)
+	} else {
+		vh.logger.Warn("⚠️ No direct emitter configured, speech event not sent")
+	}
+}
+
 // GetAudioEventChannel returns the audio event channel (deprecated, kept for compatibility)
 func (vh *VoiceHandler) GetAudioEventChannel() <-chan AudioEvent {
 	return vh.audioEventChan
@@ -397,9 +431,17 @@ func (vh *VoiceHandler) getCacheFilePathSemantic(eventType string, data interfac
 	case "hero_health_low", "hero_health_critical", "hero_mana_low":
 		filename = fmt.Sprintf("%s.mp3", eventType)
 
-	case "hero_level_up", "hero_ultimate_ready", "hero_death":
+	case "hero_level_up", "hero_ultimate_ready", "hero_death", "hero_respawned":
 		filename = fmt.Sprintf("%s.mp3", eventType)
 
+	// Respawn countdown: one file per second (reused every death)
+	case "hero_respawn_countdown":
+		if seconds, exists := dataMap["seconds"]; exists {
+			filename = fmt.Sprintf("%s_%v.mp3", eventType, seconds)
+		} else {
+			filename = fmt.Sprintf("%s.mp3", eventType)
+		}
+
 	// Timing events - use generic filenames (reuse same audio)
 	case "catapult_timing":
 		filename = "catapult_timing.mp3"
@@ -545,8 +587,16 @@ func (vh *VoiceHandler) getMessageForEvent(eventType string, data interface{}) s
 			}
 		}
 
+		// Deaths use a different template when buyback is available
+		messageKey := eventType
+		if eventType == "hero_death" {
+			if buyback, ok := dataMap["buyback_available"].(bool); ok && buyback {
+				messageKey = "hero_death_buyback"
+			}
+		}
+
 		// Try to get message from fresh config loaded from disk
-		if msg := freshConfig.GetMessage(eventType); msg != "" {
+		if msg := freshConfig.GetMessage(messageKey); msg != "" {
 			return vh.replaceParameters(msg, dataMap)
 		}
 	} else {
@@ -563,6 +613,13 @@ func (vh *VoiceHandler) getMessageForEvent(eventType string, data interface{}) s
 		}
 	}
 
+	// Script plugins can speak their own text
+	if strings.HasPrefix(eventType, "script_") {
+		if msg, ok := dataMap["message"].(string); ok && msg != "" {
+			return vh.replaceParameters(msg, dataMap)
+		}
+	}
+
 	// Fallback to hardcoded messages
 	// Special handling for game state changes
 	if eventType == "game_state_change" {
@@ -593,12 +650,22 @@ func (vh *VoiceHandler) getMessageForEvent(eventType string, data interface{}) s
 
 	// Special handling for hero death
 	if eventType == "hero_death" {
+		if respawn, exists := dataMap["respawn_seconds"]; exists {
+			return fmt.Sprintf("Você morreu. Renasce em %v segundos", respawn)
+		}
 		if deaths, exists := dataMap["deaths"]; exists {
 			return fmt.Sprintf("Você morreu %d vez", deaths)
 		}
 		return "Você morreu!"
 	}
 
+	// Respawn countdown just says the number
+	if eventType == "hero_respawn_countdown" {
+		if seconds, exists := dataMap["seconds"]; exists {
+			return fmt.Sprintf("%v", seconds)
+		}
+	}
+
 	// Return static message
 	return vh.getStaticMessage(eventType, data)
 }
@@ -642,10 +709,14 @@ func (vh *VoiceHandler) getStaticMessage(eventType string, data interface{}) str
 	switch eventType {
 	case "hero_health_low":
 		return "Vida baixa!"
+	case "hero_health_critical":
+		return "Vida crítica!"
 	case "hero_mana_low":
 		return "Mana baixa!"
 	case "hero_death":
 		return "Você morreu!"
+	case "hero_respawned":
+		return "Você está vivo!"
 	case "hero_level_up":
 		return "Level up!"
 	case "hero_ultimate_ready":
@@ -664,6 +735,64 @@ func (vh *VoiceHandler) getStaticMessage(eventType string, data interface{}) str
 		return "Hora de stackar!"
 	case "day_night_cycle":
 		return "Atenção: mudança de ciclo em breve!"
+	case "lotus":
+		return "Lótus de cura em breve!"
+	case "outpost":
+		return "XP do posto avançado em breve!"
+	case "tormentor_spawn":
+		return "Tormentor nascendo em breve!"
+	case "tormentor_respawn":
+		return "Tormentor renascendo em breve!"
+	case "buyback_ready":
+		return "Buyback disponível!"
+	case "buyback_gold_low":
+		return "Sem ouro para buyback!"
+	case "buyback_available":
+		return "Ouro para buyback de novo!"
+	case "glyph_available":
+		return "Glyph disponível!"
+	case "enemy_glyph_available":
+		return "Glyph inimigo disponível!"
+	case "tp_ready":
+		return "TP pronto!"
+	case "item_purchased":
+		return "Item comprado!"
+	case "item_ready":
+		return "Item pronto!"
+	case "ultimate_ready":
+		return "Ultimate pronto!"
+	case "ability_ready":
+		return "Habilidade pronta!"
+	case "status_smoked":
+		return "Você está de smoke!"
+	case "smoke_broken":
+		return "Smoke quebrado!"
+	case "status_silenced":
+		return "Você está silenciado!"
+	case "status_stunned":
+		return "Você está atordoado!"
+	case "status_hexed":
+		return "Você está com hex!"
+	case "status_broken":
+		return "Passivas quebradas!"
+	case "status_magic_immune":
+		return "Imune a magia!"
+	case "magic_immune_ended":
+		return "Imunidade mágica acabou!"
+	case "status_muted":
+		return "Itens mutados!"
+	case "status_disarmed":
+		return "Você está desarmado!"
+	case "draft_pick_timer":
+		return "Tempo de escolha acabando!"
+	case "draft_reserve_time":
+		return "Tempo reserva acabando!"
+	case "strategy_time":
+		return "Tempo de estratégia!"
+	case "game_paused":
+		return "Jogo pausado"
+	case "game_unpaused":
+		return "Jogo despausado"
 	default:
 		return ""
 	}
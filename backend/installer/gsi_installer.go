@@ -1,6 +1,7 @@
 package installer
 
 import (
+	"dota-gsi/backend/config"
 	"dota-gsi/backend/i18n"
 	"os"
 	"path/filepath"
@@ -58,18 +59,32 @@ func (gi *GSIInstaller) Install() InstallResult {
 	// Generate config file path
 	configPath := filepath.Join(gsiDir, "gamestate_integration_dota-gsi.cfg")
 
-	// Check if file already exists
-	if _, err := os.Stat(configPath); err == nil {
-		gi.logger.Info("GSI config file already exists")
+	// Get (or generate) the per-install auth token
+	token, err := gi.getToken()
+	if err != nil {
+		gi.logger.WithError(err).Error("Failed to get GSI auth token")
 		return InstallResult{
-			Success: true,
-			Message: i18n.T("installer.already_installed", nil),
-			InstalledAt: configPath,
+			Success: false,
+			Message: i18n.T("installer.error_write_file", map[string]interface{}{"error": err.Error()}),
 		}
 	}
 
+	// Check if file already exists with the current content
+	// (files from older versions - no auth token, fewer data blocks - are rewritten)
+	if existing, err := os.ReadFile(configPath); err == nil {
+		if string(existing) == configContent(token) {
+			gi.logger.Info("GSI config file already exists")
+			return InstallResult{
+				Success: true,
+				Message: i18n.T("installer.already_installed", nil),
+				InstalledAt: configPath,
+			}
+		}
+		gi.logger.Info("GSI config file is outdated, rewriting")
+	}
+
 	// Write config file
-	if err := gi.writeConfigFile(configPath); err != nil {
+	if err := gi.writeConfigFile(configPath, token); err != nil {
 		gi.logger.WithError(err).Error("Failed to write GSI config file")
 		return InstallResult{
 			Success: false,
@@ -85,15 +100,33 @@ func (gi *GSIInstaller) Install() InstallResult {
 	}
 }
 
+// getToken returns the GSI auth token from app config, generating one if needed
+func (gi *GSIInstaller) getToken() (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+	return cfg.EnsureGSIToken()
+}
+
 // writeConfigFile writes the GSI configuration file
-func (gi *GSIInstaller) writeConfigFile(path string) error {
-	config := `"dota2-gsi Configuration"
+func (gi *GSIInstaller) writeConfigFile(path, token string) error {
+	return os.WriteFile(path, []byte(configContent(token)), 0644)
+}
+
+// configContent returns the GSI cfg file content for a token
+func configContent(token string) string {
+	return `"dota2-gsi Configuration"
 {
     "uri"               "http://localhost:3001/gsi"
     "timeout"           "5.0"
     "buffer"            "0.1"
     "throttle"          "0.1"
     "heartbeat"         "30.0"
+    "auth"
+    {
+        "token"             "` + token + `"
+    }
     "data"
     {
         "provider"      "1"
@@ -102,10 +135,11 @@ func (gi *GSIInstaller) writeConfigFile(path string) error {
         "hero"          "1"
         "abilities"     "1"
         "items"         "1"
+        "events"        "1"
+        "draft"         "1"
     }
 }
 `
-	return os.WriteFile(path, []byte(config), 0644)
 }
 
 // CheckInstallation checks if GSI is already installed
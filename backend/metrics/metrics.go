@@ -9,6 +9,7 @@ import (
 type Metrics struct {
 	EventsProcessed uint64
 	EventsDropped   uint64
+	EventsRejected  uint64 // GSI ticks rejected for a bad auth token
 	CacheHits       uint64
 	CacheMisses     uint64
 	StartTime       time.Time
@@ -31,6 +32,11 @@ func (m *Metrics) IncrementDropped() {
 	atomic.AddUint64(&m.EventsDropped, 1)
 }
 
+// IncrementRejected increments rejected events counter
+func (m *Metrics) IncrementRejected() {
+	atomic.AddUint64(&m.EventsRejected, 1)
+}
+
 // IncrementCacheHit increments cache hits counter
 func (m *Metrics) IncrementCacheHit() {
 	atomic.AddUint64(&m.CacheHits, 1)
@@ -60,6 +66,7 @@ func (m *Metrics) GetStats() map[string]interface{} {
 	return map[string]interface{}{
 		"events_processed":    atomic.LoadUint64(&m.EventsProcessed),
 		"events_dropped":      atomic.LoadUint64(&m.EventsDropped),
+		"events_rejected":     atomic.LoadUint64(&m.EventsRejected),
 		"cache_hits":          atomic.LoadUint64(&m.CacheHits),
 		"cache_misses":        atomic.LoadUint64(&m.CacheMisses),
 		"uptime_seconds":      time.Since(m.StartTime).Seconds(),
@@ -0,0 +1,270 @@
+package plugins
+
+import (
+	"dota-gsi/backend/config"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// Script Plugins
+// ============================================================================
+// JavaScript files in <appdata>/scripts run inside an embedded, pure-Go engine
+// (goja) with no file, network or process access. A script defines
+//
+//	function onTick(state) { ... }  // Called for every GSI tick (the full tick JSON)
+//	function onReset() { ... }      // Optional, called between matches
+//
+// and can call emit(eventType, data) and log(...). Globals keep their value
+// across ticks. Emitted events are prefixed with "script_"; a "message" field
+// in data is spoken when no message is configured for the event.
+//
+// Time, recursion and events per call are limited. Memory is not: a single
+// native call (e.g. "x".repeat(2**30)) can't be interrupted and may exhaust
+// the process, so only install scripts you trust.
+
+// DirName is the scripts folder in the app data directory
+const DirName = "scripts"
+
+// Sandbox limits
+const (
+	CallTimeout      = 50 * time.Millisecond // Budget of one onTick/onReset call (and of loading)
+	MaxCallStackSize = 256                   // Deepest recursion allowed
+	MaxFileSize      = 256 * 1024            // Bigger files are not loaded
+	MaxFailures      = 10                    // Consecutive errors before a script is disabled
+	MaxEmitsPerCall  = 5                     // Events kept per call, the rest are dropped
+)
+
+// EventPrefix starts every event emitted by a script
+const EventPrefix = "script_"
+
+// EmitFunc receives the events emitted by a script
+type EmitFunc func(eventType string, data map[string]interface{})
+
+// Script is a loaded plugin. It is not safe for concurrent use.
+type Script struct {
+	Name     string // File name without extension
+	vm       *goja.Runtime
+	onTick   goja.Callable
+	onReset  goja.Callable
+	parse    goja.Callable // JSON.parse
+	emit     EmitFunc
+	logger   *logrus.Entry
+	failures int
+	disabled bool
+	emitted  int // Events emitted during the current call
+}
+
+// Dir returns the scripts directory in the app data directory
+func Dir() (string, error) {
+	appDir, err := config.GetAppDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, DirName), nil
+}
+
+// LoadDir loads every *.js file of a directory (sorted by name). Scripts
+// that fail to load are logged and skipped; a missing directory is not an error.
+func LoadDir(dir string, logger *logrus.Entry) []*Script {
+	files, err := filepath.Glob(filepath.Join(dir, "*.js"))
+	if err != nil || len(files) == 0 {
+		return nil
+	}
+	sort.Strings(files)
+
+	scripts := make([]*Script, 0, len(files))
+	for _, file := range files {
+		script, err := Load(file, logger)
+		if err != nil {
+			logger.WithError(err).WithField("script", filepath.Base(file)).Warn("⚠️ Failed to load script")
+			continue
+		}
+		scripts = append(scripts, script)
+	}
+	return scripts
+}
+
+// Load compiles and runs a script file (its top level runs once, here)
+func Load(path string, logger *logrus.Entry) (*Script, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > MaxFileSize {
+		return nil, fmt.Errorf("script too large (%d bytes, max %d)", info.Size(), MaxFileSize)
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	program, err := goja.Compile(filepath.Base(path), string(source), false)
+	if err != nil {
+		return nil, err
+	}
+
+	script := &Script{
+		Name:   name,
+		vm:     goja.New(),
+		logger: logger.WithField("script", name),
+	}
+	script.vm.SetMaxCallStackSize(MaxCallStackSize)
+	script.installGlobals()
+
+	if err := script.guard(func() error {
+		_, err := script.vm.RunProgram(program)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	onTick, ok := goja.AssertFunction(script.vm.Get("onTick"))
+	if !ok {
+		return nil, fmt.Errorf("script has no onTick(state) function")
+	}
+	script.onTick = onTick
+	script.onReset, _ = goja.AssertFunction(script.vm.Get("onReset"))
+	script.parse, _ = goja.AssertFunction(script.vm.Get("JSON").ToObject(script.vm).Get("parse"))
+
+	return script, nil
+}
+
+// installGlobals exposes emit, log and console.log (the only way out of the sandbox)
+func (s *Script) installGlobals() {
+	s.vm.Set("emit", func(call goja.FunctionCall) goja.Value {
+		eventType := sanitizeEventType(call.Argument(0).String())
+		if eventType == "" {
+			panic(s.vm.NewTypeError("emit: invalid event type"))
+		}
+
+		data := make(map[string]interface{})
+		if exported, ok := call.Argument(1).Export().(map[string]interface{}); ok {
+			for key, value := range exported {
+				data[key] = value
+			}
+		}
+		data["script"] = s.Name
+
+		s.emitted++
+		if s.emitted > MaxEmitsPerCall {
+			return goja.Undefined()
+		}
+		if s.emit != nil {
+			s.emit(EventPrefix+eventType, data)
+		}
+		return goja.Undefined()
+	})
+
+	logFunc := func(call goja.FunctionCall) goja.Value {
+		parts := make([]string, 0, len(call.Arguments))
+		for _, arg := range call.Arguments {
+			parts = append(parts, arg.String())
+		}
+		s.logger.Info("📜 " + strings.Join(parts, " "))
+		return goja.Undefined()
+	}
+	s.vm.Set("log", logFunc)
+
+	console := s.vm.NewObject()
+	console.Set("log", logFunc)
+	s.vm.Set("console", console)
+}
+
+// OnEmit sets the receiver of emitted events
+func (s *Script) OnEmit(emit EmitFunc) {
+	s.emit = emit
+}
+
+// Disabled reports whether the script was turned off after too many errors
+func (s *Script) Disabled() bool {
+	return s.disabled
+}
+
+// Tick passes a raw GSI tick to onTick
+func (s *Script) Tick(raw []byte) error {
+	if s.disabled {
+		return nil
+	}
+
+	return s.call(func() error {
+		state, err := s.parse(goja.Undefined(), s.vm.ToValue(string(raw)))
+		if err != nil {
+			return err
+		}
+		_, err = s.onTick(goja.Undefined(), state)
+		return err
+	})
+}
+
+// Reset calls onReset (if defined) and re-enables a disabled script
+func (s *Script) Reset() error {
+	s.disabled = false
+	s.failures = 0
+	if s.onReset == nil {
+		return nil
+	}
+
+	return s.call(func() error {
+		_, err := s.onReset(goja.Undefined())
+		return err
+	})
+}
+
+// call runs a script function and counts consecutive failures
+func (s *Script) call(fn func() error) error {
+	err := s.guard(fn)
+	if s.emitted > MaxEmitsPerCall {
+		s.logger.WithField("dropped", s.emitted-MaxEmitsPerCall).Warn("⚠️ Script emitted too many events, extra events dropped")
+	}
+	if err == nil {
+		s.failures = 0
+		return nil
+	}
+
+	s.failures++
+	if s.failures >= MaxFailures {
+		s.disabled = true
+		return fmt.Errorf("disabled after %d consecutive errors: %w", s.failures, err)
+	}
+	return err
+}
+
+// guard runs fn with the time budget and turns panics into errors
+func (s *Script) guard(fn func() error) (err error) {
+	s.emitted = 0
+	timer := time.AfterFunc(CallTimeout, func() {
+		s.vm.Interrupt(fmt.Sprintf("took longer than %s", CallTimeout))
+	})
+	defer func() {
+		timer.Stop()
+		s.vm.ClearInterrupt()
+		if r := recover(); r != nil {
+			err = fmt.Errorf("script panic: %v", r)
+		}
+	}()
+
+	return fn()
+}
+
+// sanitizeEventType keeps lowercase letters, digits and "_" (max 64 chars)
+func sanitizeEventType(eventType string) string {
+	eventType = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(eventType)), EventPrefix)
+	if eventType == "" || len(eventType) > 64 {
+		return ""
+	}
+	for _, r := range eventType {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' {
+			return ""
+		}
+	}
+	return eventType
+}
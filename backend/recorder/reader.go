@@ -0,0 +1,74 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reader reads TickRecords from a recording file line by line
+type Reader struct {
+	file   *os.File
+	gz     *gzip.Reader
+	reader *bufio.Reader
+	line   int
+}
+
+// OpenRecording opens a recording file for reading
+func OpenRecording(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("not a gzip recording: %w", err)
+	}
+
+	return &Reader{
+		file:   file,
+		gz:     gz,
+		reader: bufio.NewReader(gz),
+	}, nil
+}
+
+// Next returns the next record (io.EOF when the recording ends)
+func (r *Reader) Next() (TickRecord, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			// A truncated file (app crashed while recording) ends like a normal one
+			if err == io.ErrUnexpectedEOF {
+				return TickRecord{}, io.EOF
+			}
+			return TickRecord{}, err
+		}
+		r.line++
+
+		// Skip blank lines
+		if len(line) <= 1 {
+			continue
+		}
+
+		var record TickRecord
+		if jsonErr := json.Unmarshal(line, &record); jsonErr != nil {
+			// Last line may be cut in half by a crash
+			if err != nil {
+				return TickRecord{}, io.EOF
+			}
+			return TickRecord{}, fmt.Errorf("line %d: %w", r.line, jsonErr)
+		}
+		return record, nil
+	}
+}
+
+// Close closes the recording file
+func (r *Reader) Close() error {
+	r.gz.Close()
+	return r.file.Close()
+}
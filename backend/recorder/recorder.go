@@ -0,0 +1,309 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"dota-gsi/backend/events"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// Tick Recorder
+// ============================================================================
+// Records every raw GSI tick to a gzip-compressed NDJSON file, one file per
+// match (split by map.matchid). Recordings are used to reproduce alerts that
+// fired wrong in a real game.
+
+// FileExtension is the extension of recording files
+const FileExtension = ".ndjson.gz"
+
+// Recording never slows the live pipeline: ticks queue in a large DropOldest
+// buffer and the file is flushed periodically instead of on every tick
+const (
+	bufferSize    = 1000 // About a minute and a half of ticks
+	flushInterval = time.Second
+)
+
+// TickRecord is a single line of a recording file
+type TickRecord struct {
+	Time time.Time       `json:"time"` // When the tick was received
+	Raw  json.RawMessage `json:"raw"`  // Raw GSI JSON without the auth block
+}
+
+// TickRecorder writes TickEvents to per-match NDJSON files
+type TickRecorder struct {
+	logger    *logrus.Entry
+	eventBus  *events.EventBus
+	eventChan <-chan events.TickEvent
+	stopChan  chan struct{}
+	dir       string
+
+	mu       sync.Mutex
+	enabled  bool
+	matchID  string
+	filePath string
+	file     *os.File
+	gz       *gzip.Writer
+	ticks    int64
+}
+
+// NewTickRecorder creates a new tick recorder writing into dir
+func NewTickRecorder(eventBus *events.EventBus, logger *logrus.Entry, dir string) *TickRecorder {
+	return &TickRecorder{
+		logger:    logger,
+		eventBus:  eventBus,
+		eventChan: eventBus.SubscribeWith(events.SubscribeOptions{Name: "recorder", Policy: events.DropOldest, Buffer: bufferSize}),
+		stopChan:  make(chan struct{}),
+		dir:       dir,
+	}
+}
+
+// Start begins consuming events
+func (tr *TickRecorder) Start() {
+	go tr.consume()
+	tr.logger.Info("⏺️ TickRecorder started")
+}
+
+// Stop stops the recorder and closes the current file
+func (tr *TickRecorder) Stop() {
+	close(tr.stopChan)
+	tr.eventBus.Unsubscribe(tr.eventChan)
+
+	tr.mu.Lock()
+	tr.closeFile()
+	tr.mu.Unlock()
+
+	tr.logger.Info("⏺️ TickRecorder stopped")
+}
+
+// SetEnabled turns recording on or off (closes the current file when turned off)
+func (tr *TickRecorder) SetEnabled(enabled bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.enabled = enabled
+	if !enabled {
+		tr.closeFile()
+	}
+
+	tr.logger.WithField("enabled", enabled).Info("Tick recording state changed")
+}
+
+// IsEnabled returns whether recording is on
+func (tr *TickRecorder) IsEnabled() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.enabled
+}
+
+// Status returns the current recording status
+func (tr *TickRecorder) Status() map[string]interface{} {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	file := ""
+	if tr.filePath != "" {
+		file = filepath.Base(tr.filePath)
+	}
+
+	return map[string]interface{}{
+		"enabled":   tr.enabled,
+		"recording": tr.file != nil,
+		"match_id":  tr.matchID,
+		"file":      file,
+		"ticks":     tr.ticks,
+	}
+}
+
+// consume processes TickEvents and flushes the file every flushInterval
+func (tr *TickRecorder) consume() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-tr.eventChan:
+			if !ok {
+				return
+			}
+			tr.record(event)
+		case <-ticker.C:
+			tr.flush()
+		case <-tr.stopChan:
+			return
+		}
+	}
+}
+
+// flush writes buffered ticks to disk (a crash loses at most flushInterval)
+func (tr *TickRecorder) flush() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.gz == nil {
+		return
+	}
+	if err := tr.gz.Flush(); err != nil {
+		tr.logger.WithError(err).Warn("Failed to flush recording file")
+	}
+}
+
+// record writes a single tick to the file of its match
+func (tr *TickRecorder) record(event events.TickEvent) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	// Never record replayed ticks back to disk
+	if !tr.enabled || event.Replayed {
+		return
+	}
+
+	// Only ticks inside a match are recorded (menus have no match id)
+	matchID := event.State.Map.MatchID
+	if matchID == "" {
+		return
+	}
+
+	// Split files by match
+	if matchID != tr.matchID || tr.file == nil {
+		tr.closeFile()
+		if err := tr.openFile(matchID); err != nil {
+			tr.logger.WithError(err).Error("Failed to open recording file")
+			return
+		}
+	}
+
+	raw, err := stripAuth(event.RawJSON)
+	if err != nil {
+		tr.logger.WithError(err).Warn("Failed to strip auth from tick, skipping")
+		return
+	}
+
+	line, err := json.Marshal(TickRecord{
+		Time: event.Time,
+		Raw:  raw,
+	})
+	if err != nil {
+		tr.logger.WithError(err).Warn("Failed to encode tick, skipping")
+		return
+	}
+
+	if _, err := tr.gz.Write(append(line, '\n')); err != nil {
+		tr.logger.WithError(err).Error("Failed to write tick")
+		return
+	}
+
+	tr.ticks++
+}
+
+// stripAuth removes the auth block (the per-install GSI token) from a raw
+// tick, so recordings can be shared without leaking it. Replay doesn't need it.
+func stripAuth(raw []byte) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if _, exists := fields["auth"]; !exists {
+		return json.RawMessage(raw), nil
+	}
+	delete(fields, "auth")
+	return json.Marshal(fields)
+}
+
+// openFile opens a new recording file for a match (caller holds mu)
+func (tr *TickRecorder) openFile(matchID string) error {
+	filename := fmt.Sprintf("%s_%s%s", sanitize(matchID), time.Now().Format("20060102-150405"), FileExtension)
+	path := filepath.Join(tr.dir, filename)
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	tr.file = file
+	tr.gz = gzip.NewWriter(file)
+	tr.matchID = matchID
+	tr.filePath = path
+	tr.ticks = 0
+
+	tr.logger.WithFields(logrus.Fields{
+		"match_id": matchID,
+		"file":     filename,
+	}).Info("⏺️ Recording match")
+
+	return nil
+}
+
+// closeFile flushes and closes the current recording file (caller holds mu)
+func (tr *TickRecorder) closeFile() {
+	if tr.file == nil {
+		return
+	}
+
+	if err := tr.gz.Close(); err != nil {
+		tr.logger.WithError(err).Warn("Failed to finish recording file")
+	}
+	if err := tr.file.Close(); err != nil {
+		tr.logger.WithError(err).Warn("Failed to close recording file")
+	}
+
+	tr.logger.WithFields(logrus.Fields{
+		"match_id": tr.matchID,
+		"ticks":    tr.ticks,
+	}).Info("⏹️ Recording closed")
+
+	tr.file = nil
+	tr.gz = nil
+	tr.matchID = ""
+	tr.filePath = ""
+}
+
+// ListRecordings returns info about all recording files in dir (newest first)
+func ListRecordings(dir string) ([]map[string]interface{}, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	recordings := make([]map[string]interface{}, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), FileExtension) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		recordings = append(recordings, map[string]interface{}{
+			"file":     entry.Name(),
+			"size":     info.Size(),
+			"modified": info.ModTime(),
+		})
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i]["modified"].(time.Time).After(recordings[j]["modified"].(time.Time))
+	})
+
+	return recordings, nil
+}
+
+// sanitize keeps only filename-safe characters
+func sanitize(value string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, value)
+}
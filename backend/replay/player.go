@@ -0,0 +1,444 @@
+package replay
+
+import (
+	"dota-gsi/backend/events"
+	"dota-gsi/backend/recorder"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// ============================================================================
+// Replay Engine
+// ============================================================================
+// Reads a recorded tick file and publishes the ticks into the EventBus with
+// their original timing, so consumers can be checked against real matches
+// without launching Dota.
+
+// Playback states
+const (
+	StateIdle     = "idle"
+	StatePlaying  = "playing"
+	StatePaused   = "paused"
+	StateFinished = "finished"
+	StateStopped  = "stopped"
+	StateError    = "error"
+)
+
+// SpeedMax replays ticks as fast as consumers can take them
+const SpeedMax = 0
+
+// publishTimeout is how long a replayed tick waits for a full subscriber
+const publishTimeout = time.Second
+
+// noSeek marks that no seek is pending
+const noSeek = int64(-1 << 62)
+
+// Player replays recordings into the event bus
+type Player struct {
+	eventBus *events.EventBus
+	logger   *logrus.Entry
+	control  chan struct{} // Wakes the playback loop after a control change
+	onReset  func()        // Resets every consumer (ConsumerManager.ResetAll)
+
+	mu        sync.Mutex
+	state     string
+	file      string
+	speed     float64
+	seekTo    int64
+	clock     int64
+	published int64
+	lastError string
+	done      chan struct{}
+}
+
+// NewPlayer creates a new replay player publishing into eventBus
+func NewPlayer(eventBus *events.EventBus, logger *logrus.Entry) *Player {
+	return &Player{
+		eventBus: eventBus,
+		logger:   logger,
+		control:  make(chan struct{}, 1),
+		state:    StateIdle,
+		seekTo:   noSeek,
+	}
+}
+
+// SetOnReset sets the function that clears consumer state when seeking
+// backwards, so alerts already announced before the seek can fire again
+func (p *Player) SetOnReset(onReset func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onReset = onReset
+}
+
+// ParseSpeed parses a speed multiplier like "1", "10x", "2.5" or "max"
+func ParseSpeed(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "max" {
+		return SpeedMax, nil
+	}
+
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(value, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid speed %q (use a positive multiplier or \"max\")", value)
+	}
+	return speed, nil
+}
+
+// Start begins replaying a recording file (stops any playback in progress).
+// With a seek target, ticks before that game clock are skipped without being published.
+func (p *Player) Start(path string, speed float64, seek *int64) error {
+	if speed < 0 {
+		return fmt.Errorf("invalid speed %v", speed)
+	}
+
+	// Fail early if the file can't be read
+	reader, err := recorder.OpenRecording(path)
+	if err != nil {
+		return err
+	}
+	reader.Close()
+
+	p.Stop()
+
+	p.mu.Lock()
+	p.state = StatePlaying
+	p.file = path
+	p.speed = speed
+	p.seekTo = noSeek
+	if seek != nil {
+		p.seekTo = *seek
+	}
+	p.clock = 0
+	p.published = 0
+	p.lastError = ""
+	p.done = make(chan struct{})
+	done := p.done
+	p.mu.Unlock()
+
+	go p.run(path, done)
+
+	p.logger.WithFields(logrus.Fields{
+		"file":  filepath.Base(path),
+		"speed": speed,
+	}).Info("▶️ Replay started")
+
+	return nil
+}
+
+// Pause pauses playback
+func (p *Player) Pause() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state != StatePlaying {
+		return fmt.Errorf("replay is not playing")
+	}
+	p.state = StatePaused
+	p.signal()
+	return nil
+}
+
+// Resume resumes paused playback
+func (p *Player) Resume() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state != StatePaused {
+		return fmt.Errorf("replay is not paused")
+	}
+	p.state = StatePlaying
+	p.signal()
+	return nil
+}
+
+// Stop stops playback and waits for the playback loop to exit
+func (p *Player) Stop() {
+	p.mu.Lock()
+	done := p.done
+	if p.state == StatePlaying || p.state == StatePaused {
+		p.state = StateStopped
+		p.signal()
+	}
+	p.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// SetSpeed changes the speed multiplier (SpeedMax for no delay)
+func (p *Player) SetSpeed(speed float64) error {
+	if speed < 0 {
+		return fmt.Errorf("invalid speed %v", speed)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.speed = speed
+	p.signal()
+	return nil
+}
+
+// SeekTo jumps to the first tick at or after the given game clock (map.clock_time)
+func (p *Player) SeekTo(clock int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state != StatePlaying && p.state != StatePaused {
+		return fmt.Errorf("replay is not running")
+	}
+	p.seekTo = clock
+	p.signal()
+	return nil
+}
+
+// Done returns a channel closed when the current playback ends
+func (p *Player) Done() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done
+}
+
+// Status returns the current playback status
+func (p *Player) Status() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	speed := interface{}(p.speed)
+	if p.speed == SpeedMax {
+		speed = "max"
+	}
+
+	file := ""
+	if p.file != "" {
+		file = filepath.Base(p.file)
+	}
+
+	return map[string]interface{}{
+		"state":      p.state,
+		"file":       file,
+		"speed":      speed,
+		"clock_time": p.clock,
+		"published":  p.published,
+		"error":      p.lastError,
+	}
+}
+
+// signal wakes the playback loop (caller holds mu)
+func (p *Player) signal() {
+	select {
+	case p.control <- struct{}{}:
+	default:
+	}
+}
+
+// run is the playback loop
+func (p *Player) run(path string, done chan struct{}) {
+	defer close(done)
+
+	reader, err := recorder.OpenRecording(path)
+	if err != nil {
+		p.fail(err)
+		return
+	}
+	defer func() {
+		if reader != nil {
+			reader.Close()
+		}
+	}()
+
+	// Start clean: an earlier replay of the same match would otherwise
+	// suppress its alerts
+	p.reset()
+
+	seeking := noSeek
+	var lastTime time.Time
+	var record *recorder.TickRecord // Read but not published yet
+
+	for {
+		if !p.waitWhilePaused() {
+			return
+		}
+
+		// Apply pending seek (seeking backwards re-reads the file from the start)
+		if target := p.takeSeek(); target != noSeek {
+			if target < p.currentClock() {
+				reader.Close()
+				if reader, err = recorder.OpenRecording(path); err != nil {
+					p.fail(err)
+					return
+				}
+				p.reset()
+				record = nil // Re-read from the start
+			}
+			seeking = target
+			lastTime = time.Time{}
+			p.logger.WithField("clock_time", target).Info("⏩ Replay seeking")
+		}
+
+		if record == nil {
+			next, err := reader.Next()
+			if err == io.EOF {
+				p.finish()
+				return
+			}
+			if err != nil {
+				p.fail(err)
+				return
+			}
+			record = &next
+		}
+
+		clock := gjson.GetBytes(record.Raw, "map.clock_time")
+
+		// Skip ticks until we reach the seek target
+		if seeking != noSeek {
+			if !clock.Exists() || clock.Int() < seeking {
+				record = nil
+				continue
+			}
+			seeking = noSeek
+		}
+
+		// Keep the original gap between ticks. A seek requested meanwhile is
+		// checked against this same record, which may already be the target.
+		if !lastTime.IsZero() && !p.wait(record.Time.Sub(lastTime)) {
+			continue
+		}
+		lastTime = record.Time
+
+		p.eventBus.PublishWait(events.TickEvent{
+			RawJSON:  record.Raw,
+			Time:     time.Now(),
+			Replayed: true,
+		}, publishTimeout)
+
+		p.mu.Lock()
+		if clock.Exists() {
+			p.clock = clock.Int()
+		}
+		p.published++
+		p.mu.Unlock()
+		record = nil
+	}
+}
+
+// wait sleeps for a recorded gap scaled by speed.
+// Returns false if playback was stopped or a seek was requested meanwhile.
+func (p *Player) wait(gap time.Duration) bool {
+	remaining := gap
+	for remaining > 0 {
+		speed := p.currentSpeed()
+		if speed == SpeedMax {
+			return true
+		}
+
+		start := time.Now()
+		timer := time.NewTimer(time.Duration(float64(remaining) / speed))
+		select {
+		case <-timer.C:
+			return true
+		case <-p.control:
+			timer.Stop()
+			// Time played so far counts at the speed it was played at (the
+			// one read above, not a new one set by SetSpeed)
+			remaining -= time.Duration(float64(time.Since(start)) * speed)
+			if p.hasSeek() || !p.waitWhilePaused() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// reset clears consumer state before playback and after a backward seek. Ticks published before
+// the seek and still queued are dropped by generation.
+func (p *Player) reset() {
+	p.eventBus.NextGeneration()
+
+	p.mu.Lock()
+	onReset := p.onReset
+	p.mu.Unlock()
+
+	if onReset != nil {
+		onReset()
+	}
+}
+
+// waitWhilePaused blocks while paused. Returns false if playback was stopped.
+func (p *Player) waitWhilePaused() bool {
+	for {
+		p.mu.Lock()
+		state := p.state
+		p.mu.Unlock()
+
+		switch state {
+		case StatePlaying:
+			return true
+		case StatePaused:
+			<-p.control
+		default:
+			return false
+		}
+	}
+}
+
+// takeSeek returns and clears the pending seek target
+func (p *Player) takeSeek() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	target := p.seekTo
+	p.seekTo = noSeek
+	return target
+}
+
+// hasSeek checks if a seek is pending
+func (p *Player) hasSeek() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.seekTo != noSeek
+}
+
+// currentClock returns the game clock of the last published tick
+func (p *Player) currentClock() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.clock
+}
+
+// currentSpeed returns the current speed multiplier
+func (p *Player) currentSpeed() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.speed
+}
+
+// finish marks playback as finished
+func (p *Player) finish() {
+	p.mu.Lock()
+	p.state = StateFinished
+	published := p.published
+	p.mu.Unlock()
+
+	p.logger.WithField("published", published).Info("⏹️ Replay finished")
+}
+
+// fail marks playback as failed
+func (p *Player) fail(err error) {
+	p.mu.Lock()
+	p.state = StateError
+	p.lastError = err.Error()
+	p.mu.Unlock()
+
+	p.logger.WithError(err).Error("Replay failed")
+}
@@ -0,0 +1,526 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ============================================================================
+// Alert Rule Expressions
+// ============================================================================
+// A small expression language over the raw GSI tick:
+//
+//	hero.health_percent < 30 && hero.alive && map.clock_time > 600
+//	player.gold > 4000 for 20s
+//
+// Paths use gjson syntax and read missing fields as null. Supported:
+// numbers, 'strings' or "strings", true/false/null, ( ), ! and unary -,
+// * / %, + -, < <= > >=, == !=, && and ||. A trailing "for <n>[s|m]" makes
+// the condition sustained: it has to hold that long before the rule fires.
+
+// Lookup returns the value at a gjson path of the current tick
+type Lookup func(path string) gjson.Result
+
+// Expr is a compiled rule condition
+type Expr struct {
+	Source string // Condition as written
+	For    int64  // Seconds the condition must hold (0 = fire right away)
+	root   node
+	paths  []string
+}
+
+// Compile parses a condition
+func Compile(source string) (*Expr, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	expr := &Expr{Source: source, root: root}
+
+	// Optional "for 20s" suffix
+	if p.peek().kind == tokIdent && p.peek().text == "for" {
+		p.next()
+		duration := p.next()
+		if duration.kind != tokDuration && duration.kind != tokNumber {
+			return nil, fmt.Errorf("expected duration after 'for' at %d", duration.pos)
+		}
+		expr.For, err = parseDuration(duration.text)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+
+	seen := make(map[string]bool)
+	collectPaths(root, seen, &expr.paths)
+	return expr, nil
+}
+
+// Paths returns the GSI paths used by the condition (in order of appearance)
+func (e *Expr) Paths() []string {
+	return e.paths
+}
+
+// Match evaluates the condition against a tick
+func (e *Expr) Match(lookup Lookup) (bool, error) {
+	value, err := e.root.eval(lookup)
+	if err != nil {
+		return false, err
+	}
+	return truthy(value), nil
+}
+
+// ============================================================================
+// Lexer
+// ============================================================================
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokDuration // Number with an s/m unit ("20s")
+	tokString
+	tokIdent // Path or keyword
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits a condition into tokens
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isDigit(c):
+			start := i
+			for i < len(source) && (isDigit(source[i]) || source[i] == '.') {
+				i++
+			}
+			kind := tokNumber
+			if i < len(source) && (source[i] == 's' || source[i] == 'm') && (i+1 == len(source) || !isIdentChar(source[i+1])) {
+				i++
+				kind = tokDuration
+			}
+			tokens = append(tokens, token{kind, source[start:i], start})
+
+		case c == '\'' || c == '"':
+			start := i
+			i++
+			for i < len(source) && source[i] != c {
+				i++
+			}
+			if i == len(source) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			tokens = append(tokens, token{tokString, source[start+1 : i], start})
+			i++
+
+		case isIdentStart(c):
+			start := i
+			for i < len(source) && (isIdentChar(source[i]) || source[i] == '.') {
+				i++
+			}
+			text := source[start:i]
+			if strings.HasSuffix(text, ".") || strings.Contains(text, "..") {
+				return nil, fmt.Errorf("invalid path %q at %d", text, start)
+			}
+			tokens = append(tokens, token{tokIdent, text, start})
+
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")"} {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(source)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// parseDuration converts "20", "20s" or "2m" to seconds
+func parseDuration(text string) (int64, error) {
+	multiplier := int64(1)
+	if strings.HasSuffix(text, "m") {
+		multiplier = 60
+	}
+	value, err := strconv.ParseInt(strings.TrimRight(text, "sm"), 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid duration %q", text)
+	}
+	return value * multiplier, nil
+}
+
+// ============================================================================
+// Parser (precedence climbing: || < && < == != < comparisons < + - < * / %)
+// ============================================================================
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// acceptOp consumes the next token if it is one of the operators
+func (p *parser) acceptOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// binaryLevel parses a left-associative level of binary operators
+func (p *parser) binaryLevel(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op, left, right}
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.binaryLevel(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.binaryLevel(p.parseEquality, "&&")
+}
+
+func (p *parser) parseEquality() (node, error) {
+	return p.binaryLevel(p.parseComparison, "==", "!=")
+}
+
+func (p *parser) parseComparison() (node, error) {
+	return p.binaryLevel(p.parseAdditive, "<=", ">=", "<", ">")
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.binaryLevel(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.binaryLevel(p.parseUnary, "*", "/", "%")
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.acceptOp("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op, operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at %d", tok.text, tok.pos)
+		}
+		return literalNode{value}, nil
+
+	case tokString:
+		return literalNode{tok.text}, nil
+
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{true}, nil
+		case "false":
+			return literalNode{false}, nil
+		case "null":
+			return literalNode{nil}, nil
+		case "for":
+			return nil, fmt.Errorf("unexpected 'for' at %d", tok.pos)
+		}
+		return pathNode{tok.text}, nil
+
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.acceptOp(")"); !ok {
+				return nil, fmt.Errorf("missing ')' at %d", p.peek().pos)
+			}
+			return inner, nil
+		}
+	}
+
+	if tok.kind == tokEOF {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+	return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+}
+
+// ============================================================================
+// Evaluation (values are float64, string, bool or nil)
+// ============================================================================
+
+type node interface {
+	eval(lookup Lookup) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(Lookup) (interface{}, error) {
+	return n.value, nil
+}
+
+type pathNode struct {
+	path string
+}
+
+func (n pathNode) eval(lookup Lookup) (interface{}, error) {
+	result := lookup(n.path)
+	switch result.Type {
+	case gjson.Number:
+		return result.Float(), nil
+	case gjson.String:
+		return result.String(), nil
+	case gjson.True:
+		return true, nil
+	case gjson.False:
+		return false, nil
+	case gjson.JSON:
+		return result.Raw, nil // Blocks compare as their raw JSON
+	default:
+		return nil, nil
+	}
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n unaryNode) eval(lookup Lookup) (interface{}, error) {
+	value, err := n.operand.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !truthy(value), nil
+	}
+	number, ok := value.(float64)
+	if !ok {
+		return nil, nil // -null stays null
+	}
+	return -number, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n binaryNode) eval(lookup Lookup) (interface{}, error) {
+	left, err := n.left.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit logic
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(lookup)
+		return truthy(right), err
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(lookup)
+		return truthy(right), err
+	}
+
+	right, err := n.right.eval(lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	}
+
+	// Comparisons and arithmetic on missing fields are false/null, not errors
+	if left == nil || right == nil {
+		if isComparison(n.op) {
+			return false, nil
+		}
+		return nil, nil
+	}
+
+	if l, ok := left.(float64); ok {
+		if r, ok := right.(float64); ok {
+			return arithmetic(n.op, l, r)
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch n.op {
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			case "+":
+				return l + r, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("cannot apply %s to %v and %v", n.op, left, right)
+}
+
+func isComparison(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
+
+// arithmetic applies a numeric operator
+func arithmetic(op string, l, r float64) (interface{}, error) {
+	switch op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, nil
+		}
+		return l / r, nil
+	case "%":
+		// Integer modulo: a divisor in (-1, 1) truncates to 0
+		if int64(r) == 0 {
+			return nil, nil
+		}
+		return float64(int64(l) % int64(r)), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", op)
+}
+
+// truthy: null, false, 0 and "" are false
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+// collectPaths lists the distinct paths of a tree
+func collectPaths(n node, seen map[string]bool, paths *[]string) {
+	switch v := n.(type) {
+	case pathNode:
+		if !seen[v.path] {
+			seen[v.path] = true
+			*paths = append(*paths, v.path)
+		}
+	case unaryNode:
+		collectPaths(v.operand, seen, paths)
+	case binaryNode:
+		collectPaths(v.left, seen, paths)
+		collectPaths(v.right, seen, paths)
+	}
+}
@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+const testTick = `{
+	"map": {"clock_time": 700, "paused": false, "name": "dota"},
+	"player": {"gold": 4500},
+	"hero": {"health_percent": 25, "alive": true, "name": "npc_dota_hero_axe"}
+}`
+
+func testLookup(path string) gjson.Result {
+	return gjson.Get(testTick, path)
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		source string
+		kinds  []tokenKind
+		texts  []string
+	}{
+		{"hero.alive", []tokenKind{tokIdent}, []string{"hero.alive"}},
+		{"1.5 + 2", []tokenKind{tokNumber, tokOp, tokNumber}, []string{"1.5", "+", "2"}},
+		{"x for 20s", []tokenKind{tokIdent, tokIdent, tokDuration}, []string{"x", "for", "20s"}},
+		{"x for 2m", []tokenKind{tokIdent, tokIdent, tokDuration}, []string{"x", "for", "2m"}},
+		{"a <= 'b c'", []tokenKind{tokIdent, tokOp, tokString}, []string{"a", "<=", "b c"}},
+		{`a != "b"`, []tokenKind{tokIdent, tokOp, tokString}, []string{"a", "!=", "b"}},
+		{"!a&&b||c", []tokenKind{tokOp, tokIdent, tokOp, tokIdent, tokOp, tokIdent}, []string{"!", "a", "&&", "b", "||", "c"}},
+	}
+
+	for _, tt := range tests {
+		tokens, err := tokenize(tt.source)
+		if err != nil {
+			t.Errorf("tokenize(%q): unexpected error %v", tt.source, err)
+			continue
+		}
+		tokens = tokens[:len(tokens)-1] // Drop EOF
+		var kinds []tokenKind
+		var texts []string
+		for _, tok := range tokens {
+			kinds = append(kinds, tok.kind)
+			texts = append(texts, tok.text)
+		}
+		if !reflect.DeepEqual(kinds, tt.kinds) || !reflect.DeepEqual(texts, tt.texts) {
+			t.Errorf("tokenize(%q) = %v %q, want %v %q", tt.source, kinds, texts, tt.kinds, tt.texts)
+		}
+	}
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	for _, source := range []string{"'open", "a.", "a..b", "a # b", "a & b"} {
+		if _, err := tokenize(source); err == nil {
+			t.Errorf("tokenize(%q): expected an error", source)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		source string
+		For    int64
+		paths  []string
+	}{
+		{"hero.alive", 0, []string{"hero.alive"}},
+		{"player.gold > 4000 for 20s", 20, []string{"player.gold"}},
+		{"player.gold > 4000 for 2m", 120, []string{"player.gold"}},
+		{"player.gold > 4000 for 15", 15, []string{"player.gold"}},
+		{"hero.alive && (player.gold > 1 || hero.alive)", 0, []string{"hero.alive", "player.gold"}},
+	}
+
+	for _, tt := range tests {
+		expr, err := Compile(tt.source)
+		if err != nil {
+			t.Errorf("Compile(%q): unexpected error %v", tt.source, err)
+			continue
+		}
+		if expr.For != tt.For {
+			t.Errorf("Compile(%q).For = %d, want %d", tt.source, expr.For, tt.For)
+		}
+		if !reflect.DeepEqual(expr.Paths(), tt.paths) {
+			t.Errorf("Compile(%q).Paths() = %q, want %q", tt.source, expr.Paths(), tt.paths)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, source := range []string{
+		"",
+		"a >",
+		"(a > 1",
+		"a > 1)",
+		"a b",
+		"for 20s",
+		"a for",
+		"a for x",
+		"a for 20s b",
+	} {
+		if _, err := Compile(source); err == nil {
+			t.Errorf("Compile(%q): expected an error", source)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		// Fields and literals
+		{"hero.alive", true},
+		{"map.paused", false},
+		{"hero.health_percent < 30 && hero.alive && map.clock_time > 600", true},
+		{"hero.name == 'npc_dota_hero_axe'", true},
+		{"map.name != \"dota\"", false},
+
+		// Precedence
+		{"1 + 2 * 3 == 7", true},
+		{"(1 + 2) * 3 == 9", true},
+		{"10 - 4 - 3 == 3", true},
+		{"-2 * 3 == -6", true},
+		{"true || false && false", true},
+		{"(true || false) && false", false},
+		{"!false && true", true},
+		{"1 < 2 == true", true},
+
+		// Missing fields are null
+		{"hero.missing", false},
+		{"hero.missing == null", true},
+		{"hero.missing != null", false},
+		{"hero.alive != null", true},
+		{"hero.missing > 0", false},
+		{"hero.missing < 0", false},
+		{"!hero.missing", true},
+		{"hero.missing + 1 == null", true},
+		{"-hero.missing == null", true},
+
+		// Division and modulo by zero are null, not errors
+		{"player.gold / 0 == null", true},
+		{"player.gold % 0 == null", true},
+		{"player.gold % 0.5 == null", true},
+		{"player.gold % 0.5 > 1", false},
+		{"player.gold % 7 == 6", true},
+		{"player.gold / 2 == 2250", true},
+
+		// Strings
+		{"'a' < 'b'", true},
+		{"'a' + 'b' == 'ab'", true},
+		{"''", false},
+	}
+
+	for _, tt := range tests {
+		expr, err := Compile(tt.source)
+		if err != nil {
+			t.Errorf("Compile(%q): unexpected error %v", tt.source, err)
+			continue
+		}
+		got, err := expr.Match(testLookup)
+		if err != nil {
+			t.Errorf("Match(%q): unexpected error %v", tt.source, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	for _, source := range []string{"'a' * 2", "hero.name - 1", "true < false"} {
+		expr, err := Compile(source)
+		if err != nil {
+			t.Errorf("Compile(%q): unexpected error %v", source, err)
+			continue
+		}
+		if _, err := expr.Match(testLookup); err == nil {
+			t.Errorf("Match(%q): expected an error", source)
+		}
+	}
+}
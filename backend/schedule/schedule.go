@@ -0,0 +1,252 @@
+package schedule
+
+import (
+	"dota-gsi/backend/config"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// Timing Schedule - when runes, catapults, stacks... happen in each patch
+// ============================================================================
+// Schedules are embedded JSON files (one per patch). An optional schedule.json
+// in the app data directory overrides or extends them, so a patch change only
+// needs a data update.
+
+//go:embed schedules/*.json
+var schedulesFS embed.FS
+
+// OverrideFileName is the optional user schedule in the app data directory
+const OverrideFileName = "schedule.json"
+
+// Auto selects the schedule from provider.version
+const Auto = "auto"
+
+// Rule describes when a timing happens (clock_time seconds)
+type Rule struct {
+	First    int64   `json:"first"`              // Clock of the first occurrence
+	Interval int64   `json:"interval,omitempty"` // Seconds between occurrences (0 = only once)
+	Until    int64   `json:"until,omitempty"`    // Clock of the last possible occurrence (0 = no end)
+	Times    []int64 `json:"times,omitempty"`    // Fixed occurrences (replaces first/interval)
+}
+
+// Next returns the first occurrence strictly after clock
+func (r Rule) Next(clock int64) (int64, bool) {
+	next, ok := r.next(clock)
+	if !ok || (r.Until > 0 && next > r.Until) {
+		return 0, false
+	}
+	return next, true
+}
+
+// next ignores Until
+func (r Rule) next(clock int64) (int64, bool) {
+	if len(r.Times) > 0 {
+		for _, t := range r.Times {
+			if t > clock {
+				return t, true
+			}
+		}
+		return 0, false
+	}
+
+	if clock < r.First {
+		return r.First, true
+	}
+	if r.Interval <= 0 {
+		return 0, false
+	}
+	return r.First + ((clock-r.First)/r.Interval+1)*r.Interval, true
+}
+
+// Schedule holds the rules of one patch
+type Schedule struct {
+	Patch              string          `json:"patch"`
+	MinProviderVersion int64           `json:"min_provider_version"` // Lowest provider.version this patch applies to
+	Rules              map[string]Rule `json:"rules"`                // Keyed by timing key ("bounty_rune", "stack_timing"...)
+}
+
+// Rule returns the rule for a timing key
+func (s *Schedule) Rule(key string) (Rule, bool) {
+	if s == nil {
+		return Rule{}, false
+	}
+	rule, ok := s.Rules[key]
+	return rule, ok
+}
+
+// Set holds every known schedule, ordered by MinProviderVersion
+type Set struct {
+	schedules []*Schedule
+}
+
+// Load reads the embedded schedules and applies the override file (if it exists).
+// An override with a known patch replaces that patch's rules key by key, one
+// with an empty patch applies to every schedule and any other patch is added
+// as a new patch built on the newest embedded schedule's rules.
+func Load(overridePath string) (*Set, error) {
+	set := &Set{}
+
+	files, err := fs.Glob(schedulesFS, "schedules/*.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := schedulesFS.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var schedule Schedule
+		if err := json.Unmarshal(data, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		set.schedules = append(set.schedules, &schedule)
+	}
+
+	if overridePath != "" {
+		data, err := os.ReadFile(overridePath)
+		if err == nil {
+			var override Schedule
+			if err := json.Unmarshal(data, &override); err != nil {
+				return set.sorted(), fmt.Errorf("failed to parse %s: %w", overridePath, err)
+			}
+			set.apply(&override)
+		} else if !os.IsNotExist(err) {
+			return set.sorted(), err
+		}
+	}
+
+	return set.sorted(), nil
+}
+
+// apply merges an override schedule into the set
+func (s *Set) apply(override *Schedule) {
+	matched := false
+	for _, schedule := range s.schedules {
+		if override.Patch == "" || override.Patch == schedule.Patch {
+			for key, rule := range override.Rules {
+				schedule.Rules[key] = rule
+			}
+			matched = true
+		}
+	}
+
+	if matched {
+		return
+	}
+
+	// New patch: start from the newest schedule so rules the override doesn't
+	// list (runes, timers...) don't disappear
+	rules := make(map[string]Rule)
+	if base := s.newest(); base != nil {
+		for key, rule := range base.Rules {
+			rules[key] = rule
+		}
+		if override.MinProviderVersion < base.MinProviderVersion {
+			override.MinProviderVersion = base.MinProviderVersion
+		}
+	}
+	for key, rule := range override.Rules {
+		rules[key] = rule
+	}
+	override.Rules = rules
+	s.schedules = append(s.schedules, override)
+}
+
+// newest returns the schedule with the highest MinProviderVersion (the last
+// one on a tie, like Select)
+func (s *Set) newest() *Schedule {
+	var newest *Schedule
+	for _, schedule := range s.schedules {
+		if newest == nil || schedule.MinProviderVersion >= newest.MinProviderVersion {
+			newest = schedule
+		}
+	}
+	return newest
+}
+
+// sorted orders the schedules by MinProviderVersion
+func (s *Set) sorted() *Set {
+	sort.SliceStable(s.schedules, func(i, j int) bool {
+		return s.schedules[i].MinProviderVersion < s.schedules[j].MinProviderVersion
+	})
+	return s
+}
+
+// Select returns the schedule for a patch ("" or "auto" picks the newest one
+// whose MinProviderVersion is not above providerVersion)
+func (s *Set) Select(patch string, providerVersion int64) *Schedule {
+	if len(s.schedules) == 0 {
+		return nil
+	}
+
+	if patch != "" && patch != Auto {
+		if schedule := s.Get(patch); schedule != nil {
+			return schedule
+		}
+	}
+
+	selected := s.schedules[0]
+	for _, schedule := range s.schedules {
+		if schedule.MinProviderVersion <= providerVersion {
+			selected = schedule
+		}
+	}
+	return selected
+}
+
+// Get returns the schedule of a patch (nil if unknown)
+func (s *Set) Get(patch string) *Schedule {
+	for _, schedule := range s.schedules {
+		if schedule.Patch == patch {
+			return schedule
+		}
+	}
+	return nil
+}
+
+// Patches returns the known patch names, oldest first
+func (s *Set) Patches() []string {
+	patches := make([]string, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		patches = append(patches, schedule.Patch)
+	}
+	return patches
+}
+
+var (
+	defaultSet *Set
+	once       sync.Once
+)
+
+// Default returns the schedules loaded once from the binary and the app data
+// directory. A broken override file is logged and ignored.
+func Default() *Set {
+	once.Do(func() {
+		overridePath := ""
+		if appDir, err := config.GetAppDataDir(); err == nil {
+			overridePath = filepath.Join(appDir, OverrideFileName)
+		}
+
+		set, err := Load(overridePath)
+		if err != nil {
+			logrus.WithError(err).Warn("⚠️ Failed to load schedule override, using embedded schedules")
+			if set == nil {
+				set, _ = Load("")
+			}
+		}
+		if set == nil {
+			set = &Set{}
+		}
+		defaultSet = set
+	})
+	return defaultSet
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/validation"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// AddAbilitiesEndpoints adds ability alert endpoints to the router
+func (s *GSIServer) AddAbilitiesEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/abilities/watchlist", s.handleGetAbilityWatchLists).Methods("GET")
+	router.HandleFunc("/api/abilities/watchlist/{hero}", s.handleSetAbilityWatchList).Methods("POST")
+}
+
+// handleGetAbilityWatchLists returns the watched abilities of every hero
+func (s *GSIServer) handleGetAbilityWatchLists(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	watchLists := cfg.Game.GetAbilityWatchLists()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"heroes": watchLists})
+}
+
+// handleSetAbilityWatchList replaces a hero's watched abilities (an empty list removes the hero)
+func (s *GSIServer) handleSetAbilityWatchList(w http.ResponseWriter, r *http.Request) {
+	hero := mux.Vars(r)["hero"]
+	if !strings.HasPrefix(hero, "npc_dota_hero_") {
+		hero = "npc_dota_hero_" + hero
+	}
+
+	var body struct {
+		Abilities []string `json:"abilities"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	validator := validation.NewValidator()
+	validator.ValidateAbilityName(hero)
+	for _, ability := range body.Abilities {
+		validator.ValidateAbilityName(ability)
+	}
+	if !validator.IsValid() {
+		http.Error(w, validator.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cfg.Game.Update(func() {
+		if cfg.Game.Abilities == nil {
+			cfg.Game.Abilities = &config.AbilitiesConfig{}
+		}
+
+		// Copy so readers holding the old map never see it change
+		watchLists := make(map[string][]string, len(cfg.Game.Abilities.WatchList)+1)
+		for name, abilities := range cfg.Game.Abilities.WatchList {
+			watchLists[name] = abilities
+		}
+		if len(body.Abilities) == 0 {
+			delete(watchLists, hero)
+		} else {
+			watchLists[hero] = body.Abilities
+		}
+		cfg.Game.Abilities.WatchList = watchLists
+	})
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"hero":      hero,
+		"abilities": body.Abilities,
+	}).Info("⚡ Ability watch list updated")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"hero":      hero,
+		"abilities": body.Abilities,
+	})
+}
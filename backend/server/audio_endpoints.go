@@ -95,7 +95,7 @@ func (s *GSIServer) handleGenerateAudio(w http.ResponseWriter, r *http.Request)
 	// Get warning seconds from config
 	warningSeconds := 30 // Default fallback
 	if cfg.Game != nil && cfg.Game.Timings != nil {
-		if timing, ok := cfg.Game.Timings[eventType]; ok {
+		if timing := cfg.Game.GetTimingConfig(eventType); timing != nil {
 			s.logger.WithFields(logrus.Fields{
 				"eventType": eventType,
 				"timing": timing,
@@ -119,10 +119,8 @@ func (s *GSIServer) handleGenerateAudio(w http.ResponseWriter, r *http.Request)
 
 	// Get message from config
 	var message string
-	if cfg.Game != nil && cfg.Game.Messages != nil {
-		if msg, ok := cfg.Game.Messages[eventType]; ok {
-			message = msg
-		}
+	if cfg.Game != nil {
+		message = cfg.Game.GetMessage(eventType)
 	}
 
 	// Replace placeholders
@@ -4,6 +4,7 @@ import (
 	"dota-gsi/backend/config"
 	"dota-gsi/backend/handlers"
 	"dota-gsi/backend/i18n"
+	"dota-gsi/backend/validation"
 	"encoding/json"
 	"net/http"
 
@@ -53,8 +54,16 @@ func (s *GSIServer) handleGetEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Return Events map which contains all metadata
-	events := cfg.Game.Events
+	// Return Events map which contains all metadata (copied, it may be edited meanwhile)
+	var events map[string]config.TimingEvent
+	cfg.Game.View(func() {
+		if cfg.Game.Events != nil {
+			events = make(map[string]config.TimingEvent, len(cfg.Game.Events))
+			for key, event := range cfg.Game.Events {
+				events[key] = event
+			}
+		}
+	})
 	if events == nil {
 		// Return default events if not in config
 		defaultConfig := config.DefaultGameConfig()
@@ -77,12 +86,15 @@ func (s *GSIServer) handleGetEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if event exists in config
-	if cfg.Game.Events != nil {
-		if event, exists := cfg.Game.Events[key]; exists {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(event)
-			return
-		}
+	var event config.TimingEvent
+	exists := false
+	cfg.Game.View(func() {
+		event, exists = cfg.Game.Events[key]
+	})
+	if exists {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(event)
+		return
 	}
 
 	// Fallback to default config
@@ -110,8 +122,18 @@ func (s *GSIServer) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Marshal under the read lock, write after releasing it
+	var body []byte
+	cfg.Game.View(func() {
+		body, err = json.Marshal(cfg.Game)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(cfg.Game)
+	w.Write(body)
 }
 
 // handleSaveConfig saves the entire configuration
@@ -128,47 +150,49 @@ func (s *GSIServer) handleSaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	// Update voice configuration if present
-	if voiceData, ok := updates["voice"].(map[string]interface{}); ok {
-		if cfg.Game.Voice == nil {
-			cfg.Game.Voice = make(map[string]interface{})
-		}
-		
-		// Update each voice field
-		for key, value := range voiceData {
-			cfg.Game.Voice[key] = value
-		}
-		
-		s.logger.WithFields(logrus.Fields{
-			"voice": voiceData,
-		}).Info("Updating voice configuration")
-	}
-	
-	// Update timings if present
-	if timingsData, ok := updates["timings"].(map[string]interface{}); ok {
-		if cfg.Game.Timings == nil {
-			cfg.Game.Timings = make(map[string]map[string]interface{})
+	cfg.Game.Update(func() {
+		// Update voice configuration if present
+		if voiceData, ok := updates["voice"].(map[string]interface{}); ok {
+			if cfg.Game.Voice == nil {
+				cfg.Game.Voice = make(map[string]interface{})
+			}
+			
+			// Update each voice field
+			for key, value := range voiceData {
+				cfg.Game.Voice[key] = value
+			}
+			
+			s.logger.WithFields(logrus.Fields{
+				"voice": voiceData,
+			}).Info("Updating voice configuration")
 		}
 		
-		for key, value := range timingsData {
-			if timingMap, ok := value.(map[string]interface{}); ok {
-				cfg.Game.Timings[key] = timingMap
+		// Update timings if present
+		if timingsData, ok := updates["timings"].(map[string]interface{}); ok {
+			if cfg.Game.Timings == nil {
+				cfg.Game.Timings = make(map[string]map[string]interface{})
+			}
+			
+			for key, value := range timingsData {
+				if timingMap, ok := value.(map[string]interface{}); ok {
+					cfg.Game.Timings[key] = timingMap
+				}
 			}
-		}
-	}
-	
-	// Update messages if present
-	if messagesData, ok := updates["messages"].(map[string]interface{}); ok {
-		if cfg.Game.Messages == nil {
-			cfg.Game.Messages = make(map[string]string)
 		}
 		
-		for key, value := range messagesData {
-			if msg, ok := value.(string); ok {
-				cfg.Game.Messages[key] = msg
+		// Update messages if present
+		if messagesData, ok := updates["messages"].(map[string]interface{}); ok {
+			if cfg.Game.Messages == nil {
+				cfg.Game.Messages = make(map[string]string)
+			}
+			
+			for key, value := range messagesData {
+				if msg, ok := value.(string); ok {
+					cfg.Game.Messages[key] = msg
+				}
 			}
 		}
-	}
+	})
 	
 	// Save configuration
 	configPath, _ := config.GetConfigPath()
@@ -233,7 +257,7 @@ func (s *GSIServer) handleGetTimingEnabled(w http.ResponseWriter, r *http.Reques
 	}
 	
 	enabled := false
-	if timing, exists := cfg.Game.Timings[key]; exists {
+	if timing := cfg.Game.GetTimingConfig(key); timing != nil {
 		if val, ok := timing["enabled"].(bool); ok {
 			enabled = val
 		}
@@ -256,7 +280,7 @@ func (s *GSIServer) handleGetTimingValue(w http.ResponseWriter, r *http.Request)
 	}
 	
 	value := 0
-	if timing, exists := cfg.Game.Timings[key]; exists {
+	if timing := cfg.Game.GetTimingConfig(key); timing != nil {
 		if val, ok := timing[field].(float64); ok {
 			value = int(val)
 		} else if val, ok := timing[field].(int); ok {
@@ -286,32 +310,42 @@ func (s *GSIServer) handleSetTimingValue(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	
-	// Initialize if needed
-	if cfg.Game.Timings == nil {
-		cfg.Game.Timings = make(map[string]map[string]interface{})
-	}
-	if cfg.Game.Timings[key] == nil {
-		cfg.Game.Timings[key] = make(map[string]interface{})
+	// Hero alert thresholds are percentages
+	if val, ok := body["value"].(float64); ok && field == "threshold" {
+		if validator := validation.NewValidator().ValidateThreshold(int(val)); !validator.IsValid() {
+			http.Error(w, validator.Error(), http.StatusBadRequest)
+			return
+		}
 	}
 	
-	// Handle enabled field (boolean)
-	if field == "enabled" {
-		if val, ok := body["enabled"].(bool); ok {
-			cfg.Game.Timings[key][field] = val
-			s.logger.WithFields(logrus.Fields{
-				"key":   key,
-				"field": field,
-				"value": val,
-			}).Info("Setting enabled status for " + key)
+	cfg.Game.Update(func() {
+		// Initialize if needed
+		if cfg.Game.Timings == nil {
+			cfg.Game.Timings = make(map[string]map[string]interface{})
 		}
-	} else {
-		// Handle numeric fields
-		if val, ok := body["value"].(float64); ok {
-			cfg.Game.Timings[key][field] = int(val)
-		} else if val, ok := body["value"].(int); ok {
-			cfg.Game.Timings[key][field] = val
+		if cfg.Game.Timings[key] == nil {
+			cfg.Game.Timings[key] = make(map[string]interface{})
 		}
-	}
+		
+		// Handle enabled field (boolean)
+		if field == "enabled" {
+			if val, ok := body["enabled"].(bool); ok {
+				cfg.Game.Timings[key][field] = val
+				s.logger.WithFields(logrus.Fields{
+					"key":   key,
+					"field": field,
+					"value": val,
+				}).Info("Setting enabled status for " + key)
+			}
+		} else {
+			// Handle numeric fields
+			if val, ok := body["value"].(float64); ok {
+				cfg.Game.Timings[key][field] = int(val)
+			} else if val, ok := body["value"].(int); ok {
+				cfg.Game.Timings[key][field] = val
+			}
+		}
+	})
 	
 	// Save configuration
 	configPath, _ := config.GetConfigPath()
@@ -344,12 +378,7 @@ func (s *GSIServer) handleGetMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	message := ""
-	if cfg.Game.Messages != nil {
-		if msg, ok := cfg.Game.Messages[key]; ok {
-			message = msg
-		}
-	}
+	message := cfg.Game.GetMessage(key)
 	
 	s.logger.WithFields(logrus.Fields{
 		"key": key,
@@ -377,13 +406,15 @@ func (s *GSIServer) handleSetMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	if cfg.Game.Messages == nil {
-		cfg.Game.Messages = make(map[string]string)
-	}
-	
-	if msg, ok := body["message"]; ok {
-		cfg.Game.Messages[key] = msg
-	}
+	cfg.Game.Update(func() {
+		if cfg.Game.Messages == nil {
+			cfg.Game.Messages = make(map[string]string)
+		}
+		
+		if msg, ok := body["message"]; ok {
+			cfg.Game.Messages[key] = msg
+		}
+	})
 	
 	// Save configuration
 	configPath, _ := config.GetConfigPath()
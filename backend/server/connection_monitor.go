@@ -0,0 +1,232 @@
+package server
+
+import (
+	"dota-gsi/backend/events"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ============================================================================
+// GSI Connection Monitor
+// ============================================================================
+// Tracks tick arrival so the app can tell "Dota not running" apart from
+// "GSI misconfigured" (ticks arrive but are rejected) and "ticks stopped
+// mid-match". During a match Dota sends at least one tick per game second;
+// otherwise it only sends a heartbeat every 30s (see the installed cfg).
+
+// Connection states
+const (
+	ConnectionWaiting      = "waiting"      // No tick since the server started
+	ConnectionConnected    = "connected"    // Ticks arriving as expected
+	ConnectionStale        = "stale"        // Ticks stopped for longer than expected
+	ConnectionDisconnected = "disconnected" // No tick for more than two heartbeats
+)
+
+// Connection timing thresholds
+const (
+	gsiHeartbeat      = 30 * time.Second // Matches "heartbeat" in the installed cfg
+	matchStaleAfter   = 5 * time.Second  // Silence tolerated while the match clock runs
+	idleStaleAfter    = gsiHeartbeat + 5*time.Second
+	disconnectedAfter = 2*gsiHeartbeat + 5*time.Second
+	tickRateWindow    = 10 * time.Second
+	monitorInterval   = time.Second
+)
+
+// ConnectionMonitor tracks the GSI connection state from tick arrivals
+type ConnectionMonitor struct {
+	logger   *logrus.Entry
+	stopChan chan struct{}
+
+	mu           sync.Mutex
+	emitter      func(eventName string, data interface{})
+	state        string
+	since        time.Time
+	lastTick     time.Time
+	lastRejected time.Time
+	inMatch      bool
+	ticks        int64
+	rejected     int64
+	recentTicks  []time.Time
+	provider     events.Provider
+}
+
+// NewConnectionMonitor creates a new connection monitor
+func NewConnectionMonitor(logger *logrus.Entry) *ConnectionMonitor {
+	return &ConnectionMonitor{
+		logger:   logger,
+		stopChan: make(chan struct{}),
+		state:    ConnectionWaiting,
+		since:    time.Now(),
+	}
+}
+
+// Start begins checking for stale connections
+func (cm *ConnectionMonitor) Start() {
+	go cm.run()
+	cm.logger.Info("📶 ConnectionMonitor started")
+}
+
+// Stop stops the monitor
+func (cm *ConnectionMonitor) Stop() {
+	close(cm.stopChan)
+	cm.logger.Info("📶 ConnectionMonitor stopped")
+}
+
+// SetEmitter sets the Wails event emitter used for gsi:* events
+func (cm *ConnectionMonitor) SetEmitter(emitter func(eventName string, data interface{})) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.emitter = emitter
+}
+
+// RecordTick records an accepted tick (heartbeats included)
+func (cm *ConnectionMonitor) RecordTick(state *events.GameState, at time.Time) {
+	cm.mu.Lock()
+
+	cm.lastTick = at
+	cm.ticks++
+	cm.provider = state.Provider
+	// The match clock only forces regular ticks while it's running
+	cm.inMatch = state.Map.GameState != "" && state.Map.GameState != events.GameStatePostGame && !state.Map.Paused
+
+	cm.recentTicks = append(cm.recentTicks, at)
+	cm.trimRecentTicks(at)
+
+	emit := cm.transitionLocked(ConnectionConnected, at)
+	cm.mu.Unlock()
+
+	emit()
+}
+
+// RecordRejected records a tick rejected for a bad auth token
+func (cm *ConnectionMonitor) RecordRejected(at time.Time) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.lastRejected = at
+	cm.rejected++
+}
+
+// Status returns the current connection status
+func (cm *ConnectionMonitor) Status() map[string]interface{} {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.statusLocked(time.Now())
+}
+
+// run periodically checks how long it's been since the last tick
+func (cm *ConnectionMonitor) run() {
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			cm.check(now)
+		case <-cm.stopChan:
+			return
+		}
+	}
+}
+
+// check moves to stale/disconnected when ticks stop arriving
+func (cm *ConnectionMonitor) check(now time.Time) {
+	cm.mu.Lock()
+	if cm.lastTick.IsZero() {
+		cm.mu.Unlock()
+		return
+	}
+
+	silence := now.Sub(cm.lastTick)
+	staleAfter := idleStaleAfter
+	if cm.inMatch {
+		staleAfter = matchStaleAfter
+	}
+
+	emit := func() {}
+	switch {
+	case silence > disconnectedAfter:
+		emit = cm.transitionLocked(ConnectionDisconnected, now)
+	case silence > staleAfter:
+		emit = cm.transitionLocked(ConnectionStale, now)
+	}
+	cm.mu.Unlock()
+
+	emit()
+}
+
+// transitionLocked changes state (caller holds mu). It returns a function that
+// logs and emits gsi:<state>, to be called after unlocking; a no-op if the
+// state didn't change.
+func (cm *ConnectionMonitor) transitionLocked(state string, now time.Time) func() {
+	if cm.state == state {
+		return func() {}
+	}
+
+	previous := cm.state
+	cm.state = state
+	cm.since = now
+	status := cm.statusLocked(now)
+	emitter := cm.emitter
+
+	return func() {
+		cm.logger.WithFields(logrus.Fields{
+			"from": previous,
+			"to":   state,
+		}).Info("📶 GSI connection state changed")
+
+		if emitter != nil {
+			emitter("gsi:"+state, status)
+		}
+	}
+}
+
+// trimRecentTicks drops tick times outside the rate window (caller holds mu)
+func (cm *ConnectionMonitor) trimRecentTicks(now time.Time) {
+	cutoff := now.Add(-tickRateWindow)
+	keep := 0
+	for keep < len(cm.recentTicks) && cm.recentTicks[keep].Before(cutoff) {
+		keep++
+	}
+	cm.recentTicks = cm.recentTicks[keep:]
+}
+
+// statusLocked builds the status map (caller holds mu)
+func (cm *ConnectionMonitor) statusLocked(now time.Time) map[string]interface{} {
+	cm.trimRecentTicks(now)
+
+	var lastTick interface{}
+	secondsSinceTick := float64(-1)
+	if !cm.lastTick.IsZero() {
+		lastTick = cm.lastTick
+		secondsSinceTick = now.Sub(cm.lastTick).Seconds()
+	}
+
+	var lastRejected interface{}
+	if !cm.lastRejected.IsZero() {
+		lastRejected = cm.lastRejected
+	}
+
+	return map[string]interface{}{
+		"state":              cm.state,
+		"since":              cm.since,
+		"last_tick":          lastTick,
+		"seconds_since_tick": secondsSinceTick,
+		"tick_rate":          float64(len(cm.recentTicks)) / tickRateWindow.Seconds(), // Ticks per second
+		"ticks":              cm.ticks,
+		"in_match":           cm.inMatch,
+		"rejected":           cm.rejected,
+		"last_rejected":      lastRejected,
+		// Rejected ticks newer than the last accepted one mean Dota is sending
+		// with a stale or missing auth token (cfg needs reinstalling)
+		"misconfigured": !cm.lastRejected.IsZero() && cm.lastRejected.After(cm.lastTick),
+		"provider": map[string]interface{}{
+			"name":      cm.provider.Name,
+			"appid":     cm.provider.AppID,
+			"version":   cm.provider.Version,
+			"timestamp": cm.provider.Timestamp,
+		},
+	}
+}
@@ -116,16 +116,18 @@ func (s *GSIServer) handleSaveElevenLabsConfig(w http.ResponseWriter, r *http.Re
 	if cfg.Game == nil {
 		cfg.Game = &config.GameConfig{}
 	}
-	if cfg.Game.Voice == nil {
-		cfg.Game.Voice = make(map[string]interface{})
-	}
+	cfg.Game.Update(func() {
+		if cfg.Game.Voice == nil {
+			cfg.Game.Voice = make(map[string]interface{})
+		}
 
-	// Update voice settings
-	cfg.Game.Voice["voice_id"] = elevenLabsConfig.VoiceID
-	cfg.Game.Voice["stability"] = elevenLabsConfig.Stability
-	cfg.Game.Voice["similarity"] = elevenLabsConfig.Similarity
-	cfg.Game.Voice["style"] = elevenLabsConfig.Style
-	cfg.Game.Voice["speaker_boost"] = elevenLabsConfig.SpeakerBoost
+		// Update voice settings
+		cfg.Game.Voice["voice_id"] = elevenLabsConfig.VoiceID
+		cfg.Game.Voice["stability"] = elevenLabsConfig.Stability
+		cfg.Game.Voice["similarity"] = elevenLabsConfig.Similarity
+		cfg.Game.Voice["style"] = elevenLabsConfig.Style
+		cfg.Game.Voice["speaker_boost"] = elevenLabsConfig.SpeakerBoost
+	})
 
 	// Save configuration
 	configPath, _ := config.GetConfigPath()
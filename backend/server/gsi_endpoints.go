@@ -0,0 +1,19 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddGSIEndpoints adds GSI connection endpoints to the router
+func (s *GSIServer) AddGSIEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/gsi/status", s.handleGSIStatus).Methods("GET")
+}
+
+// handleGSIStatus returns the GSI connection state, last tick time, tick rate and provider
+func (s *GSIServer) handleGSIStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.connection.Status())
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/consumers"
+	"dota-gsi/backend/validation"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddItemsEndpoints adds item alert endpoints to the router
+func (s *GSIServer) AddItemsEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/items/watchlist", s.handleGetItemWatchList).Methods("GET")
+	router.HandleFunc("/api/items/watchlist", s.handleSetItemWatchList).Methods("POST")
+}
+
+// handleGetItemWatchList returns the items announced when they come off cooldown
+func (s *GSIServer) handleGetItemWatchList(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	watchList := cfg.Game.GetItemWatchList()
+	if watchList == nil {
+		watchList = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": watchList})
+}
+
+// handleSetItemWatchList replaces the watch list and persists it
+func (s *GSIServer) handleSetItemWatchList(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Items []string `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	validator := validation.NewValidator()
+	for _, name := range body.Items {
+		validator.ValidateItemName(name)
+	}
+	if !validator.IsValid() {
+		http.Error(w, validator.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Store GSI names ("Black King Bar" -> "item_black_king_bar"), without duplicates
+	watchList := make([]string, 0, len(body.Items))
+	seen := make(map[string]bool)
+	for _, name := range body.Items {
+		name = consumers.NormalizeItemName(name)
+		if !seen[name] {
+			seen[name] = true
+			watchList = append(watchList, name)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cfg.Game.Update(func() {
+		if cfg.Game.Items == nil {
+			cfg.Game.Items = &config.ItemsConfig{}
+		}
+		cfg.Game.Items.WatchList = watchList
+	})
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithField("items", watchList).Info("🎒 Item watch list updated")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": watchList})
+}
@@ -71,8 +71,10 @@ func (s *GSIServer) handleSetMode(w http.ResponseWriter, r *http.Request) {
 	if cfg.Game == nil {
 		cfg.Game = &config.GameConfig{}
 	}
-	cfg.Game.Mode = request.Mode
-	cfg.Game.LicenseKey = request.LicenseKey
+	cfg.Game.Update(func() {
+		cfg.Game.Mode = request.Mode
+		cfg.Game.LicenseKey = request.LicenseKey
+	})
 	cfg.Mode = request.Mode
 	cfg.LicenseKey = request.LicenseKey
 
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddObjectiveEndpoints adds Roshan and Tormentor endpoints to the router
+func (s *GSIServer) AddObjectiveEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/roshan", s.handleGetRoshan).Methods("GET")
+	router.HandleFunc("/api/roshan/killed", s.handleRoshanKilled).Methods("POST")
+	router.HandleFunc("/api/tormentor", s.handleGetTormentor).Methods("GET")
+	router.HandleFunc("/api/tormentor/killed", s.handleTormentorKilled).Methods("POST")
+}
+
+// handleGetRoshan returns the Roshan respawn and Aegis timers
+func (s *GSIServer) handleGetRoshan(w http.ResponseWriter, r *http.Request) {
+	if s.consumerManager == nil || s.consumerManager.Roshan() == nil {
+		http.Error(w, "Roshan tracking not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.consumerManager.Roshan().Status())
+}
+
+// handleRoshanKilled marks Roshan as killed now (for clients without GSI events)
+func (s *GSIServer) handleRoshanKilled(w http.ResponseWriter, r *http.Request) {
+	if s.consumerManager == nil || s.consumerManager.Roshan() == nil {
+		http.Error(w, "Roshan tracking not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	status, err := s.consumerManager.Roshan().MarkKilled()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// handleGetTormentor returns the Tormentor spawn and respawn timers
+func (s *GSIServer) handleGetTormentor(w http.ResponseWriter, r *http.Request) {
+	if s.consumerManager == nil || s.consumerManager.Tormentor() == nil {
+		http.Error(w, "Tormentor tracking not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.consumerManager.Tormentor().Status())
+}
+
+// handleTormentorKilled marks a Tormentor as killed now (for clients without GSI events)
+func (s *GSIServer) handleTormentorKilled(w http.ResponseWriter, r *http.Request) {
+	if s.consumerManager == nil || s.consumerManager.Tormentor() == nil {
+		http.Error(w, "Tormentor tracking not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	status, err := s.consumerManager.Tormentor().MarkKilled()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/recorder"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddRecordingEndpoints adds tick recording endpoints to the router
+func (s *GSIServer) AddRecordingEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/recording", s.handleGetRecording).Methods("GET")
+	router.HandleFunc("/api/recording", s.handleSetRecording).Methods("POST")
+	router.HandleFunc("/api/recordings", s.handleListRecordings).Methods("GET")
+}
+
+// handleGetRecording returns the current recording status
+func (s *GSIServer) handleGetRecording(w http.ResponseWriter, r *http.Request) {
+	if s.recorder == nil {
+		http.Error(w, "Recorder not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.recorder.Status())
+}
+
+// handleSetRecording turns tick recording on or off and persists the flag
+func (s *GSIServer) handleSetRecording(w http.ResponseWriter, r *http.Request) {
+	if s.recorder == nil {
+		http.Error(w, "Recorder not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.Enabled == nil {
+		http.Error(w, "Missing 'enabled' field", http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cfg.Game.Update(func() {
+		if cfg.Game.Recording == nil {
+			cfg.Game.Recording = &config.RecordingConfig{}
+		}
+		cfg.Game.Recording.Enabled = *body.Enabled
+	})
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.recorder.SetEnabled(*body.Enabled)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.recorder.Status())
+}
+
+// handleListRecordings returns all recording files
+func (s *GSIServer) handleListRecordings(w http.ResponseWriter, r *http.Request) {
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	recordings, err := recorder.ListRecordings(dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recordings)
+}
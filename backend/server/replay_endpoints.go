@@ -0,0 +1,155 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/replay"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// AddReplayEndpoints adds replay control endpoints to the router
+func (s *GSIServer) AddReplayEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/replay/status", s.handleReplayStatus).Methods("GET")
+	router.HandleFunc("/api/replay/start", s.handleReplayStart).Methods("POST")
+	router.HandleFunc("/api/replay/pause", s.handleReplayPause).Methods("POST")
+	router.HandleFunc("/api/replay/resume", s.handleReplayResume).Methods("POST")
+	router.HandleFunc("/api/replay/stop", s.handleReplayStop).Methods("POST")
+	router.HandleFunc("/api/replay/seek", s.handleReplaySeek).Methods("POST")
+	router.HandleFunc("/api/replay/speed", s.handleReplaySpeed).Methods("POST")
+}
+
+// handleReplayStatus returns the current replay status
+func (s *GSIServer) handleReplayStatus(w http.ResponseWriter, r *http.Request) {
+	s.writeReplayStatus(w)
+}
+
+// handleReplayStart starts replaying a recording from the recordings directory
+func (s *GSIServer) handleReplayStart(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		File  string      `json:"file"`
+		Speed interface{} `json:"speed"` // 1, 10, "10x" or "max" (default 1)
+		Seek  *int64      `json:"seek"`  // Optional game clock to start from
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Security: prevent directory traversal
+	if body.File == "" || strings.Contains(body.File, "..") || strings.ContainsAny(body.File, `/\`) {
+		http.Error(w, "Invalid file", http.StatusBadRequest)
+		return
+	}
+
+	speed, err := parseSpeedValue(body.Speed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.replayer.Start(filepath.Join(dir, body.File), speed, body.Seek); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.writeReplayStatus(w)
+}
+
+// handleReplayPause pauses the replay
+func (s *GSIServer) handleReplayPause(w http.ResponseWriter, r *http.Request) {
+	if err := s.replayer.Pause(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.writeReplayStatus(w)
+}
+
+// handleReplayResume resumes the replay
+func (s *GSIServer) handleReplayResume(w http.ResponseWriter, r *http.Request) {
+	if err := s.replayer.Resume(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.writeReplayStatus(w)
+}
+
+// handleReplayStop stops the replay
+func (s *GSIServer) handleReplayStop(w http.ResponseWriter, r *http.Request) {
+	s.replayer.Stop()
+	s.writeReplayStatus(w)
+}
+
+// handleReplaySeek jumps to a game clock (map.clock_time in seconds)
+func (s *GSIServer) handleReplaySeek(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Clock *int64 `json:"clock"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if body.Clock == nil {
+		http.Error(w, "Missing 'clock' field", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.replayer.SeekTo(*body.Clock); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	s.writeReplayStatus(w)
+}
+
+// handleReplaySpeed changes the replay speed
+func (s *GSIServer) handleReplaySpeed(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Speed interface{} `json:"speed"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	speed, err := parseSpeedValue(body.Speed)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.replayer.SetSpeed(speed); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.writeReplayStatus(w)
+}
+
+// writeReplayStatus writes the replay status as JSON
+func (s *GSIServer) writeReplayStatus(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.replayer.Status())
+}
+
+// parseSpeedValue accepts a JSON number or string speed (defaults to 1x)
+func parseSpeedValue(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 1, nil
+	case float64:
+		return replay.ParseSpeed(fmt.Sprintf("%g", v))
+	case string:
+		return replay.ParseSpeed(v)
+	default:
+		return 0, fmt.Errorf("invalid speed %v", value)
+	}
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/validation"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// ruleRequest is the body of the alert rule create/update endpoints
+type ruleRequest struct {
+	Name      string `json:"name"`
+	Condition string `json:"condition"` // e.g. "player.gold > 4000 for 20s"
+	Message   string `json:"message"`   // Spoken text, supports {name}, {seconds} and {<path>} (default: the name)
+	Throttle  *int64 `json:"throttle"`  // Minimum game seconds between alerts (default config.DefaultRuleThrottle)
+}
+
+// ruleResponse is an alert rule with its message, throttle and enabled flag
+type ruleResponse struct {
+	config.AlertRule
+	Message  string `json:"message"`
+	Throttle int64  `json:"throttle"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// AddRulesEndpoints adds alert rule endpoints to the router
+func (s *GSIServer) AddRulesEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/rules", s.handleGetRules).Methods("GET")
+	router.HandleFunc("/api/rules", s.handleCreateRule).Methods("POST")
+	router.HandleFunc("/api/rules/{key}", s.handleUpdateRule).Methods("PUT")
+	router.HandleFunc("/api/rules/{key}", s.handleDeleteRule).Methods("DELETE")
+}
+
+// handleGetRules returns the user-defined alert rules
+func (s *GSIServer) handleGetRules(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rules := make([]ruleResponse, 0, len(cfg.Game.GetAlertRules()))
+	for _, rule := range cfg.Game.GetAlertRules() {
+		rules = append(rules, newRuleResponse(cfg.Game, rule))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"rules": rules})
+}
+
+// handleCreateRule adds an alert rule (its key comes from the name)
+func (s *GSIServer) handleCreateRule(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeRuleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	key := config.AlertRuleKey(body.Name)
+	if key == "" {
+		http.Error(w, "rule name needs at least one letter or digit", http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Checked and added under one lock so concurrent creates can't overwrite each other
+	rule := newAlertRule(key, body)
+	if err := cfg.Game.AddAlertRule(rule, body.Message, *body.Throttle); err != nil {
+		http.Error(w, fmt.Sprintf("rule already exists: %s", key), http.StatusConflict)
+		return
+	}
+
+	s.persistRule(w, cfg.Game, rule, *body.Throttle, http.StatusCreated)
+}
+
+// handleUpdateRule replaces an alert rule (the key stays the same)
+func (s *GSIServer) handleUpdateRule(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	body, ok := decodeRuleRequest(w, r)
+	if !ok {
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, exists := cfg.Game.GetAlertRule(key); !exists {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+
+	rule := newAlertRule(key, body)
+	cfg.Game.SetAlertRule(rule, body.Message, *body.Throttle)
+	s.persistRule(w, cfg.Game, rule, *body.Throttle, http.StatusOK)
+}
+
+// handleDeleteRule removes an alert rule with its timing, message and metadata
+func (s *GSIServer) handleDeleteRule(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !cfg.Game.RemoveAlertRule(key) {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithField("key", key).Info("📐 Alert rule removed")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// decodeRuleRequest reads and validates a rule body (writes the error response)
+func decodeRuleRequest(w http.ResponseWriter, r *http.Request) (ruleRequest, bool) {
+	var body ruleRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return body, false
+	}
+
+	if body.Throttle == nil {
+		throttle := int64(config.DefaultRuleThrottle)
+		body.Throttle = &throttle
+	}
+
+	validator := validation.NewValidator().
+		ValidateAlertRule(body.Name, body.Condition, *body.Throttle).
+		ValidateMessage(body.Message)
+	if !validator.IsValid() {
+		http.Error(w, validator.Error(), http.StatusBadRequest)
+		return body, false
+	}
+
+	return body, true
+}
+
+// newAlertRule builds the rule stored under key from a request body
+func newAlertRule(key string, body ruleRequest) config.AlertRule {
+	return config.AlertRule{
+		Key:       key,
+		Name:      body.Name,
+		Condition: body.Condition,
+	}
+}
+
+// persistRule saves the config after a rule was stored and writes the rule back
+func (s *GSIServer) persistRule(w http.ResponseWriter, game *config.GameConfig, rule config.AlertRule, throttle int64, status int) {
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"key":       rule.Key,
+		"condition": rule.Condition,
+		"throttle":  throttle,
+	}).Info("📐 Alert rule saved")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(newRuleResponse(game, rule))
+}
+
+// newRuleResponse adds the message, throttle and enabled flag stored under the rule key
+func newRuleResponse(game *config.GameConfig, rule config.AlertRule) ruleResponse {
+	throttle := int64(config.DefaultRuleThrottle)
+	if timing := game.GetTimingConfig(rule.Key); timing != nil {
+		switch val := timing["throttle"].(type) {
+		case int64:
+			throttle = val
+		case float64:
+			throttle = int64(val)
+		case int:
+			throttle = int64(val)
+		}
+	}
+
+	return ruleResponse{
+		AlertRule: rule,
+		Message:   game.GetMessage(rule.Key),
+		Throttle:  throttle,
+		Enabled:   game.IsTimingEnabled(rule.Key),
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/schedule"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddScheduleEndpoints adds timing schedule endpoints to the router
+func (s *GSIServer) AddScheduleEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/schedule", s.handleGetSchedule).Methods("GET")
+	router.HandleFunc("/api/schedule", s.handleSetSchedule).Methods("POST")
+}
+
+// handleGetSchedule returns the known patches and the selected one
+func (s *GSIServer) handleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"patches":  schedule.Default().Patches(),
+		"selected": cfg.Game.GetSchedulePatch(),
+	})
+}
+
+// handleSetSchedule selects the schedule patch ("auto" follows provider.version)
+func (s *GSIServer) handleSetSchedule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Patch string `json:"patch"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if body.Patch == "" {
+		body.Patch = schedule.Auto
+	}
+	if body.Patch != schedule.Auto && schedule.Default().Get(body.Patch) == nil {
+		http.Error(w, fmt.Sprintf("unknown schedule patch: %s", body.Patch), http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cfg.Game.Update(func() {
+		cfg.Game.Schedule = body.Patch
+	})
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithField("patch", body.Patch).Info("📅 Timing schedule selected")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"selected": body.Patch})
+}
@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"dota-gsi/backend/config"
 	"dota-gsi/backend/consumers"
 	"dota-gsi/backend/events"
 	"dota-gsi/backend/handlers"
 	"dota-gsi/backend/i18n"
 	"dota-gsi/backend/metrics"
+	"dota-gsi/backend/recorder"
+	"dota-gsi/backend/replay"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -15,6 +18,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
 )
 
 // GSIServer handles GSI POST requests and publishes events
@@ -27,14 +31,19 @@ type GSIServer struct {
 	voiceHandler    interface{} // Will be set if voice is enabled
 	consumerManager *consumers.ConsumerManager
 	startTime       time.Time
+	config          *config.Config // App config (source of the GSI auth token)
+	recorder        *recorder.TickRecorder
+	replayer        *replay.Player
+	connection      *ConnectionMonitor // Tracks tick arrival (connected/stale/disconnected)
 }
 
 // NewGSIServer creates a new GSI server with event streaming
 func NewGSIServer(port int, logger *logrus.Entry, eventBus *events.EventBus) *GSIServer {
 	return &GSIServer{
-		eventBus: eventBus,
-		logger:   logger,
-		port:     port,
+		eventBus:   eventBus,
+		logger:     logger,
+		port:       port,
+		connection: NewConnectionMonitor(logger.WithField("component", "gsi-connection")),
 	}
 }
 
@@ -57,9 +66,20 @@ func New() (*GSIServer, error) {
 	server := NewGSIServer(3001, logEntry, eventBus)
 	server.startTime = time.Now()
 
+	// Create replay player (publishes recorded ticks into the same bus)
+	server.replayer = replay.NewPlayer(eventBus, logEntry.WithField("component", "replay"))
+
 	// Load configuration to get voice settings
 	cfg, err := config.Load()
 	
+	// Make sure a GSI auth token exists so every tick can be authenticated
+	if err == nil {
+		server.config = cfg
+		if _, err := cfg.EnsureGSIToken(); err != nil {
+			logEntry.WithError(err).Warn("Failed to ensure GSI auth token")
+		}
+	}
+
 	// Initialize i18n system with language from config
 	if err == nil {
 		language := cfg.Game.Language
@@ -73,6 +93,17 @@ func New() (*GSIServer, error) {
 		}
 	}
 	
+	// Create tick recorder (records raw ticks per match when enabled)
+	if err == nil {
+		if recordingsDir, err := config.GetRecordingsDir(); err == nil {
+			server.recorder = recorder.NewTickRecorder(eventBus, logEntry.WithField("component", "recorder"), recordingsDir)
+			server.recorder.SetEnabled(cfg.Game.IsRecordingEnabled())
+			server.recorder.Start()
+		} else {
+			logEntry.WithError(err).Warn("Failed to get recordings directory")
+		}
+	}
+
 	if err == nil {
 		// Create VoiceHandler (works in both free and pro mode)
 		voiceHandler, err := handlers.NewVoiceHandler(
@@ -98,9 +129,11 @@ func New() (*GSIServer, error) {
 			server.consumerManager = consumers.NewConsumerManager(logEntry.WithField("component", "consumers"))
 			handlerList := []handlers.Handler{voiceHandler}
 
-			// Add rune and timing consumers
-			server.consumerManager.AddRuneConsumer(eventBus, handlerList, cfg.Game)
-			server.consumerManager.AddTimingConsumer(eventBus, handlerList, cfg.Game)
+			// Add game consumers (runes, timings)
+			server.consumerManager.AddGameConsumers(eventBus, handlerList, cfg.Game)
+
+			// Replays reset the consumers when seeking backwards
+			server.replayer.SetOnReset(server.consumerManager.ResetAll)
 
 			// Start all consumers
 			server.consumerManager.StartAll()
@@ -130,6 +163,7 @@ func (s *GSIServer) SetVoiceHandler(handler interface{}) {
 // SetEventEmitter sets the event emitter callback for Wails
 func (s *GSIServer) SetEventEmitter(emitter func(eventName string, data interface{})) {
 	s.eventEmitter = emitter
+	s.connection.SetEmitter(emitter)
 	s.logger.Info("📡 Event emitter configured for GSI server")
 	
 	// Also set it on the voice handler if available
@@ -164,6 +198,33 @@ func (s *GSIServer) Start(addr string) error {
 
 	// Add audio endpoints
 	s.AddAudioEndpoints(router)
+
+	// Add recording endpoints
+	s.AddRecordingEndpoints(router)
+
+	// Add replay endpoints
+	s.AddReplayEndpoints(router)
+
+	// Add GSI connection endpoints
+	s.AddGSIEndpoints(router)
+
+	// Add objective endpoints (Roshan, Tormentor)
+	s.AddObjectiveEndpoints(router)
+
+	// Add item alert endpoints
+	s.AddItemsEndpoints(router)
+
+	// Add ability alert endpoints
+	s.AddAbilitiesEndpoints(router)
+
+	// Add timing schedule endpoints
+	s.AddScheduleEndpoints(router)
+
+	// Add custom timer endpoints
+	s.AddTimersEndpoints(router)
+
+	// Add alert rule endpoints
+	s.AddRulesEndpoints(router)
 	router.Use(s.corsMiddleware)
 
 	// Create HTTP server
@@ -172,6 +233,9 @@ func (s *GSIServer) Start(addr string) error {
 		Handler: router,
 	}
 
+	// Watch for ticks stopping (emits gsi:stale / gsi:disconnected)
+	s.connection.Start()
+
 	s.logger.WithField("addr", addr).Info("🚀 GSI Server starting - Event Publisher Only")
 
 	return s.server.ListenAndServe()
@@ -181,11 +245,26 @@ func (s *GSIServer) Start(addr string) error {
 func (s *GSIServer) Stop() error {
 	s.logger.Info("🛑 Shutting down GSI Server...")
 
+	// Stop any replay in progress
+	if s.replayer != nil {
+		s.replayer.Stop()
+	}
+
+	// Stop watching the GSI connection
+	if s.server != nil {
+		s.connection.Stop()
+	}
+
 	// Stop consumers first
 	if s.consumerManager != nil {
 		s.consumerManager.StopAll()
 	}
 
+	// Close the current recording file
+	if s.recorder != nil {
+		s.recorder.Stop()
+	}
+
 	if s.server == nil {
 		return nil
 	}
@@ -208,15 +287,32 @@ func (s *GSIServer) handleGSI(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
+	// Reject ticks that don't carry our per-install auth token (only the
+	// token is read, so unauthenticated requests never cost a full decode)
+	if !s.isAuthorized(gjson.GetBytes(body, "auth.token").String()) {
+		s.logger.WithField("remote", r.RemoteAddr).Warn("🚫 GSI tick rejected - invalid auth token")
+		metrics.Instance.IncrementRejected()
+		s.connection.RecordRejected(time.Now())
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	// Log that we received GSI data
 	s.logger.WithField("size", len(body)).Info("📡 GSI DATA RECEIVED!")
 
-	// Create TickEvent with raw JSON
+	// Decode the tick once - the bus shares this state with every consumer
+	state := events.ParseGameState(body)
+
+	// Create TickEvent with raw JSON and decoded state
 	tickEvent := events.TickEvent{
 		RawJSON: body,
 		Time:    time.Now(),
+		State:   state,
 	}
 
+	// Track tick arrival for the connection state
+	s.connection.RecordTick(state, tickEvent.Time)
+
 	// Publish to event bus - all consumers will receive it
 	s.eventBus.Publish(tickEvent)
 
@@ -225,6 +321,20 @@ func (s *GSIServer) handleGSI(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// isAuthorized checks the tick's auth.token against the configured GSI token
+func (s *GSIServer) isAuthorized(token string) bool {
+	if s.config == nil {
+		return false
+	}
+
+	expected := s.config.GetGSIToken()
+	if expected == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
 // handleHealth returns server health status with metrics
 func (s *GSIServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	// Build health response with metrics
@@ -239,6 +349,11 @@ func (s *GSIServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if s.consumerManager != nil {
 		stats["consumers"] = s.consumerManager.Count()
 	}
+
+	// Per-subscriber delivery, drops and lag
+	if s.eventBus != nil {
+		stats["subscribers"] = s.eventBus.Stats()
+	}
 	
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"dota-gsi/backend/config"
+	"dota-gsi/backend/validation"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// timerRequest is the body of the custom timer create/update endpoints
+type timerRequest struct {
+	Name     string  `json:"name"`
+	Message  string  `json:"message"` // Spoken text, supports {seconds} (default: the name)
+	First    int64   `json:"first"`
+	Interval int64   `json:"interval"`
+	Until    int64   `json:"until"`
+	Warnings []int64 `json:"warnings"`
+}
+
+// timerResponse is a custom timer with its message and enabled flag
+type timerResponse struct {
+	config.CustomTimer
+	Message string `json:"message"`
+	Enabled bool   `json:"enabled"`
+}
+
+// AddTimersEndpoints adds custom timer endpoints to the router
+func (s *GSIServer) AddTimersEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/timers", s.handleGetTimers).Methods("GET")
+	router.HandleFunc("/api/timers", s.handleCreateTimer).Methods("POST")
+	router.HandleFunc("/api/timers/{key}", s.handleUpdateTimer).Methods("PUT")
+	router.HandleFunc("/api/timers/{key}", s.handleDeleteTimer).Methods("DELETE")
+}
+
+// handleGetTimers returns the user-defined timers
+func (s *GSIServer) handleGetTimers(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timers := make([]timerResponse, 0, len(cfg.Game.GetCustomTimers()))
+	for _, timer := range cfg.Game.GetCustomTimers() {
+		timers = append(timers, newTimerResponse(cfg.Game, timer))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"timers": timers})
+}
+
+// handleCreateTimer adds a custom timer (its key comes from the name)
+func (s *GSIServer) handleCreateTimer(w http.ResponseWriter, r *http.Request) {
+	body, ok := decodeTimerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	key := config.CustomTimerKey(body.Name)
+	if key == "" {
+		http.Error(w, "timer name needs at least one letter or digit", http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, exists := cfg.Game.GetCustomTimer(key); exists {
+		http.Error(w, fmt.Sprintf("timer already exists: %s", key), http.StatusConflict)
+		return
+	}
+
+	s.saveTimer(w, cfg.Game, key, body, http.StatusCreated)
+}
+
+// handleUpdateTimer replaces a custom timer (the key stays the same)
+func (s *GSIServer) handleUpdateTimer(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	body, ok := decodeTimerRequest(w, r)
+	if !ok {
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, exists := cfg.Game.GetCustomTimer(key); !exists {
+		http.Error(w, "Timer not found", http.StatusNotFound)
+		return
+	}
+
+	s.saveTimer(w, cfg.Game, key, body, http.StatusOK)
+}
+
+// handleDeleteTimer removes a custom timer with its timing, message and metadata
+func (s *GSIServer) handleDeleteTimer(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	cfg, err := config.Load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !cfg.Game.RemoveCustomTimer(key) {
+		http.Error(w, "Timer not found", http.StatusNotFound)
+		return
+	}
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, cfg.Game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithField("key", key).Info("⏱️ Custom timer removed")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// decodeTimerRequest reads and validates a timer body (writes the error response)
+func decodeTimerRequest(w http.ResponseWriter, r *http.Request) (timerRequest, bool) {
+	var body timerRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return body, false
+	}
+
+	validator := validation.NewValidator().
+		ValidateCustomTimer(body.Name, body.First, body.Interval, body.Until, body.Warnings).
+		ValidateMessage(body.Message)
+	if !validator.IsValid() {
+		http.Error(w, validator.Error(), http.StatusBadRequest)
+		return body, false
+	}
+
+	return body, true
+}
+
+// saveTimer stores a custom timer, persists the config and writes it back
+func (s *GSIServer) saveTimer(w http.ResponseWriter, game *config.GameConfig, key string, body timerRequest, status int) {
+	timer := config.CustomTimer{
+		Key:      key,
+		Name:     body.Name,
+		First:    body.First,
+		Interval: body.Interval,
+		Until:    body.Until,
+		Warnings: body.Warnings,
+	}
+	game.SetCustomTimer(timer, body.Message)
+
+	// Save configuration
+	configPath, _ := config.GetConfigPath()
+	if err := config.SaveGameConfig(configPath, game); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	timer, _ = game.GetCustomTimer(key)
+	s.logger.WithFields(logrus.Fields{
+		"key":      key,
+		"first":    timer.First,
+		"interval": timer.Interval,
+		"until":    timer.Until,
+		"warnings": timer.Warnings,
+	}).Info("⏱️ Custom timer saved")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(newTimerResponse(game, timer))
+}
+
+// newTimerResponse adds the message and enabled flag stored under the timer key
+func newTimerResponse(game *config.GameConfig, timer config.CustomTimer) timerResponse {
+	return timerResponse{
+		CustomTimer: timer,
+		Message:     game.GetMessage(timer.Key),
+		Enabled:     game.IsTimingEnabled(timer.Key),
+	}
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"dota-gsi/backend/rules"
 	"fmt"
 	"strings"
 )
@@ -20,16 +21,51 @@ func NewValidator() *Validator {
 // ValidateTimingKey validates a timing key
 func (v *Validator) ValidateTimingKey(key string) *Validator {
 	validKeys := map[string]bool{
-		"bounty_rune":      true,
-		"power_rune":       true,
-		"water_rune":       true,
-		"wisdom_rune":      true,
-		"stack_timing":     true,
-		"day_night_cycle":  true,
-		"catapult_timing":  true,
+		"bounty_rune":            true,
+		"power_rune":             true,
+		"water_rune":             true,
+		"wisdom_rune":            true,
+		"stack_timing":           true,
+		"day_night_cycle":        true,
+		"catapult_timing":        true,
+		"game_pause":             true,
+		"draft_pick_timer":       true,
+		"draft_reserve_time":     true,
+		"strategy_time":          true,
+		"roshan":                 true,
+		"tormentor":              true,
+		"lotus":                  true,
+		"outpost":                true,
+		"buyback":                true,
+		"glyph":                  true,
+		"tp_ready":               true,
+		"item_purchased":         true,
+		"item_ready":             true,
+		"ultimate_ready":         true,
+		"ability_ready":          true,
+		"hero_health_low":        true,
+		"hero_health_critical":   true,
+		"hero_mana_low":          true,
+		"hero_death":             true,
+		"hero_level_up":          true,
+		"hero_respawn_countdown": true,
+		"hero_respawned":         true,
+		"game_state_change":      true,
+		"day_night_change":       true,
+		"score_change":           true,
+		"status_smoked":          true,
+		"smoke_broken":           true,
+		"status_silenced":        true,
+		"status_stunned":         true,
+		"status_hexed":           true,
+		"status_broken":          true,
+		"status_magic_immune":    true,
+		"magic_immune_ended":     true,
+		"status_muted":           true,
+		"status_disarmed":        true,
 	}
 	
-	if !validKeys[key] {
+	if !validKeys[key] && !strings.HasPrefix(key, "custom_") && !strings.HasPrefix(key, "rule_") {
 		v.errors = append(v.errors, fmt.Sprintf("invalid timing key: %s", key))
 	}
 	
@@ -41,8 +77,13 @@ func (v *Validator) ValidateTimingField(field string) *Validator {
 	validFields := map[string]bool{
 		"enabled":         true,
 		"warning_seconds": true,
-		"first_spawn":     true,
-		"interval":        true,
+		"minimum":         true,
+		"maximum":         true,
+		"aegis":           true,
+		"time":            true,
+		"enemy":           true,
+		"threshold":       true,
+		"throttle":        true,
 	}
 	
 	if !validFields[field] {
@@ -61,6 +102,60 @@ func (v *Validator) ValidateTimingValue(value int) *Validator {
 	return v
 }
 
+// ValidateThreshold validates a percent threshold (hero health/mana alerts)
+func (v *Validator) ValidateThreshold(value int) *Validator {
+	if value < 1 || value > 100 {
+		v.errors = append(v.errors, fmt.Sprintf("threshold out of range (1-100): %d", value))
+	}
+	
+	return v
+}
+
+// ValidateCustomTimer validates a user-defined timer (clock_time seconds)
+func (v *Validator) ValidateCustomTimer(name string, first, interval, until int64, warnings []int64) *Validator {
+	if strings.TrimSpace(name) == "" || len(name) > 50 {
+		v.errors = append(v.errors, "timer name must have 1-50 characters")
+	}
+	if first < 0 || first > 10800 {
+		v.errors = append(v.errors, fmt.Sprintf("timer first occurrence out of range (0-10800): %d", first))
+	}
+	if interval != 0 && (interval < 5 || interval > 3600) {
+		v.errors = append(v.errors, fmt.Sprintf("timer interval out of range (0 or 5-3600): %d", interval))
+	}
+	if until != 0 && until < first {
+		v.errors = append(v.errors, fmt.Sprintf("timer end (%d) is before its first occurrence (%d)", until, first))
+	}
+	if len(warnings) > 5 {
+		v.errors = append(v.errors, "too many timer warnings (max 5)")
+	}
+	for _, warning := range warnings {
+		if warning < 0 || warning > 300 {
+			v.errors = append(v.errors, fmt.Sprintf("timer warning out of range (0-300): %d", warning))
+		} else if interval > 0 && warning >= interval {
+			v.errors = append(v.errors, fmt.Sprintf("timer warning (%d) must be shorter than its interval (%d)", warning, interval))
+		}
+	}
+	
+	return v
+}
+
+// ValidateAlertRule validates a user-defined alert rule (the condition must compile)
+func (v *Validator) ValidateAlertRule(name, condition string, throttle int64) *Validator {
+	if strings.TrimSpace(name) == "" || len(name) > 50 {
+		v.errors = append(v.errors, "rule name must have 1-50 characters")
+	}
+	if strings.TrimSpace(condition) == "" || len(condition) > 500 {
+		v.errors = append(v.errors, "rule condition must have 1-500 characters")
+	} else if _, err := rules.Compile(condition); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("invalid rule condition: %v", err))
+	}
+	if throttle < 0 || throttle > 3600 {
+		v.errors = append(v.errors, fmt.Sprintf("rule throttle out of range (0-3600): %d", throttle))
+	}
+	
+	return v
+}
+
 // ValidateMessage validates a custom message
 func (v *Validator) ValidateMessage(message string) *Validator {
 	if len(message) > 500 {
@@ -83,22 +178,94 @@ func (v *Validator) ValidateMessage(message string) *Validator {
 // ValidateEventType validates an event type for audio
 func (v *Validator) ValidateEventType(eventType string) *Validator {
 	validTypes := map[string]bool{
-		"bounty_rune":     true,
-		"power_rune":      true,
-		"water_rune":      true,
-		"wisdom_rune":     true,
-		"stack_timing":    true,
-		"day_night_cycle": true,
-		"catapult_timing": true,
+		"bounty_rune":            true,
+		"power_rune":             true,
+		"water_rune":             true,
+		"wisdom_rune":            true,
+		"stack_timing":           true,
+		"day_night_cycle":        true,
+		"catapult_timing":        true,
+		"roshan_killed":          true,
+		"roshan_min_window":      true,
+		"roshan_max_window":      true,
+		"aegis_expiring":         true,
+		"tormentor_spawn":        true,
+		"tormentor_respawn":      true,
+		"lotus":                  true,
+		"outpost":                true,
+		"buyback_ready":          true,
+		"buyback_gold_low":       true,
+		"buyback_available":      true,
+		"glyph_available":        true,
+		"enemy_glyph_available":  true,
+		"tp_ready":               true,
+		"item_purchased":         true,
+		"item_ready":             true,
+		"ultimate_ready":         true,
+		"ability_ready":          true,
+		"draft_pick_timer":       true,
+		"draft_reserve_time":     true,
+		"strategy_time":          true,
+		"hero_health_low":        true,
+		"hero_health_critical":   true,
+		"hero_mana_low":          true,
+		"hero_death":             true,
+		"hero_death_buyback":     true,
+		"hero_respawn_countdown": true,
+		"hero_respawned":         true,
+		"status_smoked":          true,
+		"smoke_broken":           true,
+		"status_silenced":        true,
+		"status_stunned":         true,
+		"status_hexed":           true,
+		"status_broken":          true,
+		"status_magic_immune":    true,
+		"magic_immune_ended":     true,
+		"status_muted":           true,
+		"status_disarmed":        true,
 	}
 	
-	if !validTypes[eventType] {
+	if !validTypes[eventType] && !strings.HasPrefix(eventType, "custom_") && !strings.HasPrefix(eventType, "rule_") {
 		v.errors = append(v.errors, fmt.Sprintf("invalid event type: %s", eventType))
 	}
 	
 	return v
 }
 
+// ValidateItemName validates a watch-list item name (e.g. "item_blink" or "blink")
+func (v *Validator) ValidateItemName(name string) *Validator {
+	if name == "" || len(name) > 64 {
+		v.errors = append(v.errors, fmt.Sprintf("invalid item name: %q", name))
+		return v
+	}
+
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '_' && r != ' ' {
+			v.errors = append(v.errors, fmt.Sprintf("invalid item name: %q", name))
+			break
+		}
+	}
+
+	return v
+}
+
+// ValidateAbilityName validates a hero or ability name (e.g. "npc_dota_hero_axe", "axe_berserkers_call")
+func (v *Validator) ValidateAbilityName(name string) *Validator {
+	if name == "" || len(name) > 64 {
+		v.errors = append(v.errors, fmt.Sprintf("invalid name: %q", name))
+		return v
+	}
+
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' {
+			v.errors = append(v.errors, fmt.Sprintf("invalid name: %q", name))
+			break
+		}
+	}
+
+	return v
+}
+
 // IsValid returns whether validation passed
 func (v *Validator) IsValid() bool {
 	return len(v.errors) == 0
@@ -17,14 +17,16 @@ export function Footer({ theme }: FooterProps) {
   const [connected, setConnected] = useState(false);
 
   useEffect(() => {
-    // Escutar status de conexão
-    const unsubConnection = EventsOn("game:connection", (data: any) => {
-      setConnected(data.connected);
-    });
+    // Escutar status de conexão do GSI
+    const unsubConnected = EventsOn("gsi:connected", () => setConnected(true));
+    const unsubStale = EventsOn("gsi:stale", () => setConnected(false));
+    const unsubDisconnected = EventsOn("gsi:disconnected", () => setConnected(false));
 
     // Cleanup
     return () => {
-      unsubConnection();
+      unsubConnected();
+      unsubStale();
+      unsubDisconnected();
     };
   }, []);
 
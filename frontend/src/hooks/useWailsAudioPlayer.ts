@@ -1,8 +1,10 @@
 import { useEffect, useRef, useState } from 'react';
 import { EventsOn, EventsOff } from '../../wailsjs/runtime';
+import i18n from '@/i18n/config';
 
 interface AudioEvent {
   filename: string;
+  text?: string; // FREE mode events without an embedded file are spoken by the system voice
   eventType: string;
   data: Record<string, any>;
   timestamp: number;
@@ -40,7 +42,11 @@ export function useWailsAudioPlayer() {
     });
 
     try {
-      await playAudio(event.filename);
+      if (event.text) {
+        await speakText(event.text);
+      } else {
+        await playAudio(event.filename);
+      }
     } catch (error) {
       console.error('Failed to play audio:', error);
     }
@@ -84,6 +90,24 @@ export function useWailsAudioPlayer() {
     });
   };
 
+  // Speak text with the system voice (no recording for this event)
+  const speakText = async (text: string): Promise<void> => {
+    return new Promise((resolve, reject) => {
+      if (!('speechSynthesis' in window)) {
+        reject(new Error('Speech synthesis not available'));
+        return;
+      }
+
+      console.log('🗣️ Speaking text (FREE mode, no embedded audio):', text);
+      const utterance = new SpeechSynthesisUtterance(text);
+      utterance.lang = i18n.language || 'pt-BR';
+      utterance.onend = () => resolve();
+      utterance.onerror = () => reject(new Error(`Failed to speak "${text}"`));
+
+      window.speechSynthesis.speak(utterance);
+    });
+  };
+
   // Add audio event to queue
   const enqueueAudio = (event: AudioEvent) => {
     // Check if we should play this audio
@@ -127,6 +151,7 @@ export function useWailsAudioPlayer() {
       // Try to handle the event regardless of structure
       const event: AudioEvent = {
         filename: audioEvent.filename || audioEvent.Filename || 'unknown.mp3',
+        text: audioEvent.text || audioEvent.Text,
         eventType: audioEvent.eventType || audioEvent.EventType || 'unknown',
         data: audioEvent.data || audioEvent.Data || {},
         timestamp: audioEvent.timestamp || audioEvent.Timestamp || Date.now()
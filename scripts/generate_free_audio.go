@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,15 +17,60 @@ const (
 	VOICE_ID = "eVXYtPVYB9wDoz9NVTIy"
 )
 
-// Mensagens genéricas para versão FREE (sem placeholder {seconds})
+// Mensagens genéricas para versão FREE (sem placeholder {seconds}).
+// Os arquivos que ainda não foram gerados e commitados em backend/assets/audio
+// são falados pela voz do sistema no modo FREE.
 var messages = map[string]string{
-	"bounty_rune_warning.mp3":        "Runa de Recompensa em alguns segundos",
-	"power_rune_warning.mp3":         "Runa de Poder em alguns segundos",
-	"wisdom_rune_warning.mp3":        "Runa de Sabedoria em alguns segundos",
-	"water_rune_warning.mp3":         "Runa de Água em alguns segundos",
-	"stack_timing_warning.mp3":       "Hora de stackar em alguns segundos",
-	"catapult_timing_warning.mp3":    "Catapulta chegando em alguns segundos",
-	"day_night_cycle_warning.mp3":    "Mudança de ciclo em alguns segundos",
+	"bounty_rune_warning.mp3":              "Runa de Recompensa em alguns segundos",
+	"power_rune_warning.mp3":               "Runa de Poder em alguns segundos",
+	"wisdom_rune_warning.mp3":              "Runa de Sabedoria em alguns segundos",
+	"water_rune_warning.mp3":               "Runa de Água em alguns segundos",
+	"stack_timing_warning.mp3":             "Hora de stackar em alguns segundos",
+	"catapult_timing_warning.mp3":          "Catapulta chegando em alguns segundos",
+	"day_night_cycle_warning.mp3":          "Mudança de ciclo em alguns segundos",
+	"roshan_killed_warning.mp3":            "Roshan morto",
+	"roshan_min_window_warning.mp3":        "Roshan pode renascer em alguns segundos",
+	"roshan_max_window_warning.mp3":        "Roshan renasce em alguns segundos",
+	"aegis_expiring_warning.mp3":           "Aegis expirando em alguns segundos",
+	"lotus_warning.mp3":                    "Lótus de cura em alguns segundos",
+	"outpost_warning.mp3":                  "XP do posto avançado em alguns segundos",
+	"tormentor_spawn_warning.mp3":          "Tormentor nascendo em alguns segundos",
+	"tormentor_respawn_warning.mp3":        "Tormentor renascendo em alguns segundos",
+	"buyback_ready_warning.mp3":            "Buyback disponível",
+	"buyback_gold_low_warning.mp3":         "Sem ouro para buyback",
+	"buyback_available_warning.mp3":        "Ouro para buyback de novo",
+	"glyph_available_warning.mp3":          "Glyph disponível",
+	"enemy_glyph_available_warning.mp3":    "Glyph inimigo disponível",
+	"tp_ready_warning.mp3":                 "TP pronto",
+	"item_purchased_warning.mp3":           "Item comprado",
+	"item_ready_warning.mp3":               "Item pronto",
+	"ultimate_ready_warning.mp3":           "Ultimate pronto",
+	"ability_ready_warning.mp3":            "Habilidade pronta",
+	"hero_health_low_warning.mp3":          "Vida baixa",
+	"hero_health_critical_warning.mp3":     "Vida crítica",
+	"hero_mana_low_warning.mp3":            "Mana baixa",
+	"hero_death_warning.mp3":               "Você morreu",
+	"hero_respawned_warning.mp3":           "Você está vivo",
+	"hero_respawn_countdown_1_warning.mp3": "1",
+	"hero_respawn_countdown_2_warning.mp3": "2",
+	"hero_respawn_countdown_3_warning.mp3": "3",
+	"hero_respawn_countdown_4_warning.mp3": "4",
+	"hero_respawn_countdown_5_warning.mp3": "5",
+	"status_smoked_warning.mp3":            "Você está de smoke",
+	"smoke_broken_warning.mp3":             "Smoke quebrado",
+	"status_silenced_warning.mp3":          "Você está silenciado",
+	"status_stunned_warning.mp3":           "Você está atordoado",
+	"status_hexed_warning.mp3":             "Você está com hex",
+	"status_broken_warning.mp3":            "Passivas quebradas",
+	"status_magic_immune_warning.mp3":      "Imune a magia",
+	"magic_immune_ended_warning.mp3":       "Imunidade mágica acabou",
+	"status_muted_warning.mp3":             "Itens mutados",
+	"status_disarmed_warning.mp3":          "Você está desarmado",
+	"draft_pick_timer_warning.mp3":         "Tempo de escolha acabando",
+	"draft_reserve_time_warning.mp3":       "Tempo reserva acabando",
+	"strategy_time_warning.mp3":            "Tempo de estratégia",
+	"game_paused_warning.mp3":              "Jogo pausado",
+	"game_unpaused_warning.mp3":            "Jogo despausado",
 }
 
 type ElevenLabsRequest struct {
@@ -88,6 +134,9 @@ func generateAudio(text, filename string) error {
 }
 
 func main() {
+	force := flag.Bool("force", false, "Regenerar também os arquivos que já existem")
+	flag.Parse()
+
 	fmt.Println("🎵 Gerando áudios para versão FREE com ElevenLabs...")
 	fmt.Println()
 
@@ -116,11 +165,18 @@ func main() {
 	fmt.Println()
 
 	successCount := 0
+	skippedCount := 0
 	totalFiles := len(messages)
 
 	for filename, text := range messages {
 		outputPath := filepath.Join(outputDir, filename)
 
+		// Só gera os que faltam (a voz dos existentes não muda sem -force)
+		if _, err := os.Stat(outputPath); err == nil && !*force {
+			skippedCount++
+			continue
+		}
+
 		fmt.Printf("⏳ Gerando: %s...", filename)
 
 		if err := generateAudio(text, outputPath); err != nil {
@@ -144,7 +200,7 @@ func main() {
 	}
 
 	fmt.Println()
-	fmt.Printf("🎉 Concluído! %d/%d arquivos gerados\n", successCount, totalFiles)
+	fmt.Printf("🎉 Concluído! %d/%d arquivos gerados (%d já existiam)\n", successCount, totalFiles-skippedCount, skippedCount)
 	fmt.Println()
 	fmt.Println("📦 Próximo passo:")
 	fmt.Println("   wails build")